@@ -3,7 +3,9 @@ package analyzer
 import (
 	"bytes"
 	"fmt"
+	"go/ast"
 	"go/token"
+	"io"
 	"os"
 	"path/filepath"
 	"strings"
@@ -34,6 +36,10 @@ var Analyzer = &analysis.Analyzer{
 	Run:  runAnalyzer,
 }
 
+// UnusedSuppressionCategory is a diagnostic category of the -report-unused-ignores reports.
+// It's not a valid Go identifier, so it never collides with the rule group names.
+const UnusedSuppressionCategory = "unused-suppression"
+
 // ForceNewEngine disables engine cache optimization.
 // This should only be useful for analyzer testing.
 var ForceNewEngine = false
@@ -46,6 +52,21 @@ var (
 	globalEngineErrored bool
 )
 
+// StatsWriter is where the -stats table of every analyzed package is printed.
+//
+// go vet runs a separate analyzer process per package, so the table
+// is printed once per process there. Drivers that print the
+// aggregated Stats() table on their own can set it to nil.
+var StatsWriter io.Writer = os.Stderr
+
+var (
+	// globalStats accumulates the stats of all Analyzer runs when -stats is set.
+	globalStats = ruleguard.NewRuleStats()
+
+	// statsWriterMu serializes the StatsWriter writes of the concurrent runs.
+	statsWriterMu sync.Mutex
+)
+
 var (
 	flagRules   string
 	flagE       string
@@ -54,6 +75,15 @@ var (
 
 	flagGoVersion string
 
+	flagMinSeverity string
+
+	flagReportUnusedIgnores bool
+
+	flagBaseline      string
+	flagBaselineWrite string
+
+	flagStats bool
+
 	flagDebug              string
 	flagDebugFunc          string
 	flagDebugImports       bool
@@ -67,6 +97,11 @@ func init() {
 	Analyzer.Flags.BoolVar(&flagDebugEnableDisable, "debug-enable-disable", false, "[experimental!] enable debug for -enable/-disable related info")
 
 	Analyzer.Flags.StringVar(&flagGoVersion, "go", "", "select the Go version to target; leave as string for the latest")
+	Analyzer.Flags.StringVar(&flagMinSeverity, "min-severity", "info", "don't report diagnostics below this severity level: info, warning or error")
+	Analyzer.Flags.BoolVar(&flagReportUnusedIgnores, "report-unused-ignores", false, "report //ruleguard:ignore directives that don't suppress anything")
+	Analyzer.Flags.StringVar(&flagBaseline, "baseline", "", "don't report findings that are recorded in the specified baseline file")
+	Analyzer.Flags.StringVar(&flagBaselineWrite, "baseline-write", "", "record all findings into the specified baseline file instead of reporting them")
+	Analyzer.Flags.BoolVar(&flagStats, "stats", false, "print per-rule match counts and timings to the stderr after the analysis")
 
 	Analyzer.Flags.StringVar(&flagRules, "rules", "", "comma-separated list of ruleguard file paths")
 	Analyzer.Flags.StringVar(&flagE, "e", "", "execute a single rule from a given string")
@@ -97,6 +132,31 @@ func runAnalyzer(pass *analysis.Pass) (interface{}, error) {
 		return nil, fmt.Errorf("parse Go version: %w", err)
 	}
 
+	minSeverity, err := ruleguard.ParseSeverity(flagMinSeverity)
+	if err != nil {
+		return nil, fmt.Errorf("parse min severity: %w", err)
+	}
+
+	if flagBaseline != "" && flagBaselineWrite != "" {
+		return nil, fmt.Errorf("-baseline and -baseline-write can't be used together")
+	}
+	var knownFindings *baseline
+	var remaining map[baselineKey]int
+	if flagBaseline != "" {
+		knownFindings, err = getBaseline(flagBaseline)
+		if err != nil {
+			return nil, err
+		}
+		remaining = make(map[baselineKey]int)
+	}
+	var newFindings *baseline
+	if flagBaselineWrite != "" {
+		newFindings, err = newBaseline(flagBaselineWrite)
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	ctx := &ruleguard.RunContext{
 		Debug:        flagDebug,
 		DebugImports: flagDebugImports,
@@ -106,7 +166,28 @@ func runAnalyzer(pass *analysis.Pass) (interface{}, error) {
 		Sizes:        pass.TypesSizes,
 		Fset:         pass.Fset,
 		GoVersion:    goVersion,
+		Fingerprints: knownFindings != nil || newFindings != nil,
 		Report: func(data *ruleguard.ReportData) {
+			if data.Severity < minSeverity {
+				return
+			}
+			if newFindings != nil {
+				filename := pass.Fset.Position(data.Node.Pos()).Filename
+				newFindings.counts[newFindings.keyOf(filename, data)]++
+				return
+			}
+			if knownFindings != nil {
+				filename := pass.Fset.Position(data.Node.Pos()).Filename
+				key := knownFindings.keyOf(filename, data)
+				n, ok := remaining[key]
+				if !ok {
+					n = knownFindings.counts[key]
+				}
+				remaining[key] = n - 1
+				if n > 0 {
+					return // A known finding
+				}
+			}
 			fullMessage := data.Message
 			info := data.RuleInfo
 			if printRuleLocation {
@@ -114,27 +195,52 @@ func runAnalyzer(pass *analysis.Pass) (interface{}, error) {
 					info.Group.Name, data.Message, filepath.Base(info.Group.Filename), info.Line)
 			}
 			diag := analysis.Diagnostic{
-				Pos:     data.Node.Pos(),
-				Message: fullMessage,
+				Pos:      data.Node.Pos(),
+				End:      data.Node.End(),
+				Category: info.Group.Name,
+				Message:  fullMessage,
 			}
-			if data.Suggestion != nil {
-				s := data.Suggestion
-				diag.SuggestedFixes = []analysis.SuggestedFix{
-					{
-						Message: "suggested replacement",
-						TextEdits: []analysis.TextEdit{
-							{
-								Pos:     s.From,
-								End:     s.To,
-								NewText: s.Replacement,
-							},
-						},
-					},
+			for _, s := range data.Suggestions {
+				label := s.Label
+				if label == "" {
+					label = "suggested replacement"
 				}
+				edits := make([]analysis.TextEdit, 0, len(s.Edits)+1)
+				edits = append(edits, analysis.TextEdit{
+					Pos:     s.From,
+					End:     s.To,
+					NewText: s.Replacement,
+				})
+				for _, e := range s.Edits {
+					edits = append(edits, analysis.TextEdit{
+						Pos:     e.From,
+						End:     e.To,
+						NewText: e.Replacement,
+					})
+				}
+				diag.SuggestedFixes = append(diag.SuggestedFixes, analysis.SuggestedFix{
+					Message:   label,
+					TextEdits: edits,
+				})
 			}
 			pass.Report(diag)
 		},
 	}
+	var pkgStats *ruleguard.RuleStats
+	if flagStats {
+		pkgStats = ruleguard.NewRuleStats()
+		ctx.Stats = pkgStats
+	}
+	if flagReportUnusedIgnores {
+		ctx.ReportUnusedSuppression = func(comment *ast.Comment) {
+			pass.Report(analysis.Diagnostic{
+				Pos:      comment.Pos(),
+				End:      comment.End(),
+				Category: UnusedSuppressionCategory,
+				Message:  "unused suppression directive: " + comment.Text,
+			})
+		}
+	}
 
 	if runnerStatePool.New != nil {
 		state := runnerStatePool.Get().(*ruleguard.RunnerState)
@@ -144,8 +250,23 @@ func runAnalyzer(pass *analysis.Pass) (interface{}, error) {
 		}()
 	}
 
-	for _, f := range pass.Files {
-		if err := engine.Run(ctx, f); err != nil {
+	if err := engine.RunPackage(ctx, pass.Files); err != nil {
+		return nil, err
+	}
+
+	if pkgStats != nil {
+		globalStats.Merge(pkgStats)
+		if err := writePackageStats(pass.Pkg.Path(), pkgStats); err != nil {
+			return nil, err
+		}
+	}
+
+	if newFindings != nil {
+		filenames := make([]string, len(pass.Files))
+		for i, f := range pass.Files {
+			filenames[i] = pass.Fset.Position(f.Pos()).Filename
+		}
+		if err := updateBaseline(flagBaselineWrite, filenames, newFindings.counts); err != nil {
 			return nil, err
 		}
 	}
@@ -153,6 +274,46 @@ func runAnalyzer(pass *analysis.Pass) (interface{}, error) {
 	return nil, nil
 }
 
+// writePackageStats prints the stats table of a single package to the StatsWriter.
+func writePackageStats(pkgPath string, stats *ruleguard.RuleStats) error {
+	if StatsWriter == nil {
+		return nil
+	}
+	// The table is formatted in advance, so it's
+	// not interleaved with the other packages output.
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "ruleguard stats for %s:\n", pkgPath)
+	if err := stats.WriteTable(&buf); err != nil {
+		return err
+	}
+	statsWriterMu.Lock()
+	defer statsWriterMu.Unlock()
+	_, err := StatsWriter.Write(buf.Bytes())
+	return err
+}
+
+// LoadedGroups returns the rule groups that are used by the Analyzer.
+//
+// Rules are loaded during the first Analyzer run,
+// so it returns nil if the Analyzer was never executed.
+func LoadedGroups() []ruleguard.GoRuleGroup {
+	globalEngineMu.Lock()
+	defer globalEngineMu.Unlock()
+
+	if globalEngine == nil {
+		return nil
+	}
+	return globalEngine.LoadedGroups()
+}
+
+// Stats returns the per-rule stats collected by all Analyzer runs so far.
+//
+// The stats are collected only if the -stats flag is set,
+// otherwise the result is empty.
+func Stats() *ruleguard.RuleStats {
+	return globalStats
+}
+
 func prepareEngine() (*ruleguard.Engine, error) {
 	if ForceNewEngine {
 		return newEngine()
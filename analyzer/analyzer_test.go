@@ -2,6 +2,7 @@ package analyzer_test
 
 import (
 	"bytes"
+	"encoding/json"
 	"fmt"
 	"go/token"
 	"io/fs"
@@ -13,7 +14,10 @@ import (
 	"testing"
 
 	"github.com/google/go-cmp/cmp"
+	"golang.org/x/tools/go/analysis"
 	"golang.org/x/tools/go/analysis/analysistest"
+	"golang.org/x/tools/go/analysis/checker"
+	"golang.org/x/tools/go/packages"
 
 	"github.com/quasilyte/go-ruleguard/analyzer"
 	"github.com/quasilyte/go-ruleguard/ruleguard/goutil"
@@ -45,7 +49,17 @@ var tests = []struct {
 	{name: "stdlib"},
 	{name: "uber"},
 	{name: "localfunc"},
+	{name: "funccontext"},
+	{name: "ancestors"},
+	{name: "siblings"},
+	{name: "pkgscope"},
+	{name: "definedby"},
+	{name: "callgraph"},
+	{name: "controlflow"},
 	{name: "goversion", flags: map[string]string{"go": "1.16"}},
+	{name: "severity", flags: map[string]string{"min-severity": "warning"}},
+	{name: "suppress", flags: map[string]string{"report-unused-ignores": "true"}},
+	{name: "baseline", flags: map[string]string{"baseline": "./testdata/src/baseline/baseline.json"}},
 	{name: "imports"},
 	{name: "generics"},
 
@@ -124,6 +138,168 @@ func TestAnalyzer(t *testing.T) {
 	}
 }
 
+// setAnalyzerFlags sets the analyzer flags for the duration of the test.
+func setAnalyzerFlags(t *testing.T, flags map[string]string) {
+	t.Helper()
+	for key, val := range flags {
+		f := analyzer.Analyzer.Flags.Lookup(key)
+		if f == nil {
+			t.Fatalf("flag %s is not defined", key)
+		}
+		prev := f.Value.String()
+		if err := f.Value.Set(val); err != nil {
+			t.Fatalf("set %s flag: %v", key, err)
+		}
+		t.Cleanup(func() {
+			if err := f.Value.Set(prev); err != nil {
+				t.Errorf("reset %s flag: %v", key, err)
+			}
+		})
+	}
+}
+
+// analyzeTestPackage runs the Analyzer over the testdata package
+// and returns its root actions.
+func analyzeTestPackage(t *testing.T, name string) []*checker.Action {
+	t.Helper()
+	testdata := analysistest.TestData()
+	pkgs, err := packages.Load(&packages.Config{
+		Mode: packages.NeedName | packages.NeedFiles | packages.NeedCompiledGoFiles |
+			packages.NeedImports | packages.NeedTypes | packages.NeedTypesSizes |
+			packages.NeedSyntax | packages.NeedTypesInfo,
+		Dir: filepath.Join(testdata, "src", name),
+		Env: append(os.Environ(), "GOPATH="+testdata, "GO111MODULE=off", "GOPROXY=off"),
+	}, name)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if packages.PrintErrors(pkgs) != 0 {
+		t.Fatalf("failed to load the %s package", name)
+	}
+	graph, err := checker.Analyze([]*analysis.Analyzer{analyzer.Analyzer}, pkgs, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, act := range graph.Roots {
+		if act.Err != nil {
+			t.Fatalf("analyze %s: %v", act.Package, act.Err)
+		}
+	}
+	return graph.Roots
+}
+
+func TestStats(t *testing.T) {
+	prevForceNewEngine := analyzer.ForceNewEngine
+	analyzer.ForceNewEngine = true
+	t.Cleanup(func() { analyzer.ForceNewEngine = prevForceNewEngine })
+
+	var buf bytes.Buffer
+	prevStatsWriter := analyzer.StatsWriter
+	analyzer.StatsWriter = &buf
+	t.Cleanup(func() { analyzer.StatsWriter = prevStatsWriter })
+
+	setAnalyzerFlags(t, map[string]string{
+		"rules":    "./testdata/src/baseline/rules.go",
+		"baseline": "",
+		"stats":    "true",
+	})
+	analyzeTestPackage(t, "baseline")
+
+	lines := strings.Split(buf.String(), "\n")
+	if len(lines) < 3 || lines[0] != "ruleguard stats for baseline:" || !strings.HasPrefix(lines[1], "RULE ") {
+		t.Fatalf("unexpected stats output:\n%s", buf.String())
+	}
+	for _, group := range []string{"panicCall", "printlnCall"} {
+		if !strings.Contains(buf.String(), "\n"+group+" (rules.go:") {
+			t.Errorf("no %s row in the stats output:\n%s", group, buf.String())
+		}
+	}
+}
+
+func TestBaselineWrite(t *testing.T) {
+	prevForceNewEngine := analyzer.ForceNewEngine
+	analyzer.ForceNewEngine = true
+	t.Cleanup(func() { analyzer.ForceNewEngine = prevForceNewEngine })
+
+	// The existing findings of the other packages are preserved:
+	// every go vet package process writes its findings to the same file.
+	// The findings of the analyzed files are replaced, so the
+	// fixed ones are dropped; the deleted files findings are dropped too.
+	dir := t.TempDir()
+	filename := filepath.Join(dir, "baseline.json")
+	if err := os.WriteFile(filepath.Join(dir, "other.go"), []byte("package other\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	targetFilename, err := filepath.Abs(filepath.Join("testdata", "src", "baseline", "target.go"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	targetRel, err := filepath.Rel(dir, targetFilename)
+	if err != nil {
+		t.Fatal(err)
+	}
+	existing := fmt.Sprintf(`{"findings": [
+		{"group": "otherCall", "file": "other.go", "fingerprint": "0123456789abcdef", "count": 2},
+		{"group": "otherCall", "file": "deleted.go", "fingerprint": "0123456789abcdef", "count": 1},
+		{"group": "panicCall", "file": %q, "fingerprint": "fedcba9876543210", "count": 1},
+		{"group": "printlnCall", "file": %q, "fingerprint": "933a2af720aedfa3", "count": 5}
+	]}`, filepath.ToSlash(targetRel), filepath.ToSlash(targetRel))
+	if err := os.WriteFile(filename, []byte(existing), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	setAnalyzerFlags(t, map[string]string{
+		"rules":          "./testdata/src/baseline/rules.go",
+		"baseline":       "",
+		"baseline-write": filename,
+	})
+
+	for _, act := range analyzeTestPackage(t, "baseline") {
+		// In the write mode nothing is reported.
+		for _, diag := range act.Diagnostics {
+			t.Errorf("%s: unexpected diagnostic: %s", act.Package.Fset.Position(diag.Pos), diag.Message)
+		}
+	}
+
+	data, err := os.ReadFile(filename)
+	if err != nil {
+		t.Fatal(err)
+	}
+	type finding struct {
+		Group       string
+		File        string
+		Fingerprint string
+		Count       int
+	}
+	var result struct {
+		Findings []finding
+	}
+	if err := json.Unmarshal(data, &result); err != nil {
+		t.Fatal(err)
+	}
+	for i, f := range result.Findings {
+		if f.File == "other.go" {
+			continue
+		}
+		if !strings.HasSuffix(f.File, "/baseline/target.go") {
+			t.Fatalf("unexpected baseline file path: %s", f.File)
+		}
+		result.Findings[i].File = "target.go"
+	}
+	want := []finding{
+		{Group: "panicCall", File: "target.go", Fingerprint: "886183b0a0283719", Count: 1},
+		{Group: "panicCall", File: "target.go", Fingerprint: "bfd17857b86952ca", Count: 1},
+		{Group: "printlnCall", File: "target.go", Fingerprint: "933a2af720aedfa3", Count: 3},
+		{Group: "otherCall", File: "other.go", Fingerprint: "0123456789abcdef", Count: 2},
+	}
+	if diff := cmp.Diff(want, result.Findings); diff != "" {
+		t.Errorf("baseline mismatch (-want +have):\n%s", diff)
+	}
+	if _, err := os.Stat(filename + ".lock"); !os.IsNotExist(err) {
+		t.Errorf("baseline lock file is not removed")
+	}
+}
+
 func TestE2E(t *testing.T) {
 	wd, err := os.Getwd()
 	if err != nil {
@@ -0,0 +1,232 @@
+package analyzer
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/quasilyte/go-ruleguard/ruleguard"
+)
+
+// baselineKey identifies a finding without relying on its line number.
+type baselineKey struct {
+	Group       string `json:"group"`
+	File        string `json:"file"`
+	Fingerprint string `json:"fingerprint"`
+}
+
+type baselineEntry struct {
+	baselineKey
+
+	// Count is a number of identical findings inside the file.
+	Count int `json:"count"`
+}
+
+type baselineFile struct {
+	Findings []baselineEntry `json:"findings"`
+}
+
+// baseline is a set of known findings.
+//
+// Files are recorded relative to the baseline file directory,
+// so the baseline can be shared between different machines.
+type baseline struct {
+	filename string
+	dir      string
+	counts   map[baselineKey]int
+}
+
+func newBaseline(filename string) (*baseline, error) {
+	abs, err := filepath.Abs(filename)
+	if err != nil {
+		return nil, err
+	}
+	return &baseline{
+		filename: filename,
+		dir:      filepath.Dir(abs),
+		counts:   make(map[baselineKey]int),
+	}, nil
+}
+
+func loadBaseline(filename string) (*baseline, error) {
+	b, err := newBaseline(filename)
+	if err != nil {
+		return nil, err
+	}
+	data, err := os.ReadFile(filename)
+	if err != nil {
+		return nil, err
+	}
+	var f baselineFile
+	if err := json.Unmarshal(data, &f); err != nil {
+		return nil, fmt.Errorf("%s: %w", filename, err)
+	}
+	for _, e := range f.Findings {
+		b.counts[e.baselineKey] += e.Count
+	}
+	return b, nil
+}
+
+func (b *baseline) keyOf(filename string, data *ruleguard.ReportData) baselineKey {
+	return baselineKey{
+		Group:       data.RuleInfo.Group.Name,
+		File:        b.relPath(filename),
+		Fingerprint: data.Fingerprint,
+	}
+}
+
+// relPath returns a baseline file path for the analyzed filename.
+func (b *baseline) relPath(filename string) string {
+	rel, err := filepath.Rel(b.dir, filename)
+	if err != nil {
+		rel = filename
+	}
+	return filepath.ToSlash(rel)
+}
+
+// replace records the findings of a single package.
+//
+// All old findings of the analyzed files are removed, so the fixed
+// findings don't stay in the baseline. The findings of the files
+// that don't exist anymore are removed too.
+// Other files findings are kept as is: they belong to the
+// packages that are not analyzed by this run (or not yet).
+//
+// The same file can be analyzed more than once (e.g. as a part of
+// a package and its test variant), but its findings are the same,
+// so the last write wins.
+func (b *baseline) replace(filenames []string, counts map[baselineKey]int) {
+	analyzed := make(map[string]bool, len(filenames))
+	for _, filename := range filenames {
+		analyzed[b.relPath(filename)] = true
+	}
+	for k := range b.counts {
+		if analyzed[k.File] || !fileExists(filepath.Join(b.dir, filepath.FromSlash(k.File))) {
+			delete(b.counts, k)
+		}
+	}
+	for k, n := range counts {
+		b.counts[k] = n
+	}
+}
+
+func fileExists(filename string) bool {
+	_, err := os.Stat(filename)
+	return err == nil
+}
+
+func (b *baseline) write() error {
+	f := baselineFile{Findings: make([]baselineEntry, 0, len(b.counts))}
+	for k, n := range b.counts {
+		f.Findings = append(f.Findings, baselineEntry{baselineKey: k, Count: n})
+	}
+	sort.Slice(f.Findings, func(i, j int) bool {
+		x := f.Findings[i]
+		y := f.Findings[j]
+		if x.File != y.File {
+			return x.File < y.File
+		}
+		if x.Group != y.Group {
+			return x.Group < y.Group
+		}
+		return x.Fingerprint < y.Fingerprint
+	})
+	data, err := json.MarshalIndent(f, "", "  ")
+	if err != nil {
+		return err
+	}
+	// Write to a temporary file first, so the concurrent readers
+	// never see a partially written baseline.
+	tmpFilename := b.filename + ".tmp"
+	if err := os.WriteFile(tmpFilename, append(data, '\n'), 0o644); err != nil {
+		return err
+	}
+	return os.Rename(tmpFilename, b.filename)
+}
+
+var (
+	baselineMu     sync.Mutex
+	globalBaseline *baseline
+)
+
+// baselineLockTimeout is how long updateBaseline waits for other
+// processes that are writing the same baseline file.
+const baselineLockTimeout = 30 * time.Second
+
+// getBaseline returns a -baseline file contents.
+// The file is loaded only once.
+func getBaseline(filename string) (*baseline, error) {
+	baselineMu.Lock()
+	defer baselineMu.Unlock()
+
+	if globalBaseline != nil && globalBaseline.filename == filename {
+		return globalBaseline, nil
+	}
+	b, err := loadBaseline(filename)
+	if err != nil {
+		return nil, fmt.Errorf("load baseline: %w", err)
+	}
+	globalBaseline = b
+	return b, nil
+}
+
+// updateBaseline records the package findings to the -baseline-write file.
+// The filenames are the analyzed package files.
+//
+// Analyzers have no "finish" hook, so the file is re-written
+// after every analyzed package. Some drivers (like go vet -vettool)
+// run every package in a separate process, so we can't accumulate
+// the findings in memory: the file is read and updated every time.
+// See baseline.replace for the details.
+func updateBaseline(filename string, filenames []string, counts map[baselineKey]int) error {
+	baselineMu.Lock()
+	defer baselineMu.Unlock()
+
+	unlock, err := lockBaseline(filename)
+	if err != nil {
+		return fmt.Errorf("write baseline: %w", err)
+	}
+	defer unlock()
+
+	b, err := loadBaseline(filename)
+	switch {
+	case errors.Is(err, os.ErrNotExist):
+		b, err = newBaseline(filename)
+		if err != nil {
+			return err
+		}
+	case err != nil:
+		return fmt.Errorf("write baseline: %w", err)
+	}
+	b.replace(filenames, counts)
+	if err := b.write(); err != nil {
+		return fmt.Errorf("write baseline: %w", err)
+	}
+	return nil
+}
+
+// lockBaseline acquires an exclusive lock for the baseline file.
+// It works across the processes by creating a lock file next to it.
+func lockBaseline(filename string) (unlock func(), err error) {
+	lockFilename := filename + ".lock"
+	deadline := time.Now().Add(baselineLockTimeout)
+	for {
+		f, err := os.OpenFile(lockFilename, os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0o644)
+		if err == nil {
+			f.Close()
+			return func() { os.Remove(lockFilename) }, nil
+		}
+		if !errors.Is(err, os.ErrExist) {
+			return nil, err
+		}
+		if time.Now().After(deadline) {
+			return nil, fmt.Errorf("%s is locked; remove it if no other ruleguard process is running", lockFilename)
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
@@ -36,10 +36,8 @@ func New(ruleSet *ir.File) *analysis.Analyzer {
 				})
 			},
 		}
-		for _, f := range pass.Files {
-			if err := e.Run(runCtx, f); err != nil {
-				return nil, err
-			}
+		if err := e.RunPackage(runCtx, pass.Files); err != nil {
+			return nil, err
 		}
 		return nil, nil
 	}
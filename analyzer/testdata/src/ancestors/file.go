@@ -0,0 +1,72 @@
+package ancestors
+
+import (
+	"os"
+	"sync"
+	"time"
+)
+
+func load() []int { return nil }
+
+func deferTest(files []*os.File) {
+	for _, f := range files {
+		defer f.Close() // want `\Qdefer inside a loop`
+	}
+	for _, f := range files {
+		func() {
+			defer f.Close()
+		}()
+	}
+	for i := 0; i < len(files); i++ {
+		if i%2 == 0 {
+			defer files[i].Close() // want `\Qdefer inside a loop`
+		}
+	}
+	defer files[0].Close()
+}
+
+func loadTest() {
+	for _, x := range load() {
+		_ = x
+		load() // want `\Qload() is called on every iteration`
+	}
+	for i := len(load()); i < 10; i++ {
+	}
+	for i := 0; i < len(load()); i++ { // want `\Qload() is called on every iteration`
+	}
+	_ = load()
+}
+
+func selectTest(ch chan int) {
+	select {
+	case <-ch:
+		time.Sleep(time.Second) // want `\Qtime.Sleep inside a select case`
+	default:
+		time.Sleep(time.Second) // want `\Qtime.Sleep inside a select case`
+	}
+	time.Sleep(time.Second)
+}
+
+func goroutineTest(ch1, ch2 chan int) {
+	go func() {
+		close(ch1) // want `\Qclosing ch1 inside a goroutine`
+	}()
+	close(ch2)
+}
+
+func deferUnlockTest(mu *sync.Mutex) {
+	mu.Lock()
+	defer mu.Unlock() // want `\Qdeferred mu unlock`
+	mu.Unlock()
+}
+
+func lockedLoopTest(mu *sync.Mutex, xs []int) {
+	for range xs {
+		mu.Lock() // want `\Qmu is locked on every iteration`
+		xs[0]++
+		mu.Unlock()
+	}
+	mu.Lock()
+	xs[0]++
+	mu.Unlock()
+}
@@ -0,0 +1,44 @@
+//go:build ignore
+// +build ignore
+
+package gorules
+
+import (
+	"github.com/quasilyte/go-ruleguard/dsl"
+)
+
+func deferInLoop(m dsl.Matcher) {
+	m.Match(`defer $f($*_)`).
+		Where(m["$$"].Node.InsideLoop()).
+		Report(`defer inside a loop`)
+}
+
+func loadInLoop(m dsl.Matcher) {
+	m.Match(`load()`).
+		Where(m["$$"].Node.InsideLoop()).
+		Report(`load() is called on every iteration`)
+}
+
+func sleepInSelect(m dsl.Matcher) {
+	m.Match(`time.Sleep($_)`).
+		Where(m["$$"].Node.Ancestor("CommClause")).
+		Report(`time.Sleep inside a select case`)
+}
+
+func closeInGoroutine(m dsl.Matcher) {
+	m.Match(`close($ch)`).
+		Where(m["ch"].Node.Ancestor("GoStmt")).
+		Report(`closing $ch inside a goroutine`)
+}
+
+func unlockInDefer(m dsl.Matcher) {
+	m.Match(`$mu.Unlock()`).
+		Where(m["mu"].Node.Ancestor("DeferStmt")).
+		Report(`deferred $mu unlock`)
+}
+
+func lockedLoopBody(m dsl.Matcher) {
+	m.Match(`$mu.Lock(); $*body; $mu.Unlock()`).
+		Where(m["body"].Node.InsideLoop()).
+		Report(`$mu is locked on every iteration`)
+}
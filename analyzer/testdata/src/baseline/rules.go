@@ -0,0 +1,14 @@
+//go:build ignore
+// +build ignore
+
+package gorules
+
+import "github.com/quasilyte/go-ruleguard/dsl"
+
+func panicCall(m dsl.Matcher) {
+	m.Match(`panic($_)`).Report(`panic call`)
+}
+
+func printlnCall(m dsl.Matcher) {
+	m.Match(`println($*_)`).Report(`println call`)
+}
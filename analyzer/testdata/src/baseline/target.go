@@ -0,0 +1,17 @@
+package baseline
+
+func oldCode() {
+	panic("old")
+	println("old", 1)
+
+	// Reformatted code has the same fingerprint.
+	println("old",
+		1)
+}
+
+func newCode() {
+	panic("new") // want `\Qpanic call`
+
+	// Only 2 of these are recorded.
+	println("old", 1) // want `\Qprintln call`
+}
@@ -0,0 +1,78 @@
+package callgraph
+
+import (
+	"log"
+	"net/http"
+)
+
+func directHandler(w http.ResponseWriter, r *http.Request) { // want `\QdirectHandler handler may call log.Fatal`
+	log.Fatal("direct")
+}
+
+func indirectHandler(w http.ResponseWriter, r *http.Request) { // want `\QindirectHandler handler may call log.Fatal`
+	mustLoad()
+}
+
+func otherFileHandler(w http.ResponseWriter, r *http.Request) { // want `\QotherFileHandler handler may call log.Fatal`
+	fail("other file")
+}
+
+func deferredHandler(w http.ResponseWriter, r *http.Request) { // want `\QdeferredHandler handler may call log.Fatal`
+	defer func() {
+		mustLoad()
+	}()
+}
+
+func goroutineHandler(w http.ResponseWriter, r *http.Request) { // want `\QgoroutineHandler handler may call log.Fatal`
+	go func() {
+		log.Fatal("goroutine")
+	}()
+}
+
+func recursiveHandler(w http.ResponseWriter, r *http.Request) {
+	recursive(10)
+}
+
+func closureHandler(w http.ResponseWriter, r *http.Request) {
+	f := func() {
+		log.Fatal("closure")
+	}
+	_ = f
+}
+
+func funcValueHandler(w http.ResponseWriter, r *http.Request) {
+	f := mustLoad
+	f()
+}
+
+func interfaceHandler(w http.ResponseWriter, r *http.Request) {
+	var l loader = fatalLoader{}
+	l.Load()
+}
+
+func safeHandler(w http.ResponseWriter, r *http.Request) {
+	log.Print("safe")
+	_ = log.Fatal
+}
+
+func mustLoad() {
+	if err := load(); err != nil {
+		log.Fatal(err)
+	}
+}
+
+func load() error { return nil }
+
+func recursive(n int) {
+	if n != 0 {
+		recursive(n - 1)
+	}
+}
+
+type loader interface {
+	Load()
+}
+
+type fatalLoader struct{}
+
+func (fatalLoader) Load() { mustLoad() }
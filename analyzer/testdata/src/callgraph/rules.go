@@ -0,0 +1,26 @@
+//go:build ignore
+// +build ignore
+
+package gorules
+
+import (
+	"github.com/quasilyte/go-ruleguard/dsl"
+)
+
+func fatalHandler(m dsl.Matcher) {
+	m.Match(`func $f($_ http.ResponseWriter, $_ *http.Request) { $*_ }`).
+		Where(m["f"].Object.Calls("log.Fatal")).
+		Report(`$f handler may call log.Fatal`)
+}
+
+func exitingMethod(m dsl.Matcher) {
+	m.Match(`func ($_ $_) $f($*_) $*_ { $*_ }`).
+		Where(m["f"].Object.Calls("os.Exit")).
+		Report(`$f method may call os.Exit`)
+}
+
+func bufferWriteCall(m dsl.Matcher) {
+	m.Match(`$f($*_)`).
+		Where(m["f"].Object.Calls("(*bytes.Buffer).WriteString")).
+		Report(`$f writes to a buffer`)
+}
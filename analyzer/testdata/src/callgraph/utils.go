@@ -0,0 +1,51 @@
+package callgraph
+
+import (
+	"bytes"
+	"log"
+	"os"
+)
+
+func fail(msg string) {
+	logFatal(msg)
+}
+
+func logFatal(msg string) {
+	log.Fatal(msg)
+}
+
+type app struct{}
+
+func (a *app) stop(code int) { // want `\Qstop method may call os.Exit`
+	a.exit(code)
+}
+
+func (a *app) exit(code int) { // want `\Qexit method may call os.Exit`
+	os.Exit(code)
+}
+
+func (a *app) name() string {
+	return "app"
+}
+
+type box[T any] struct{ value T }
+
+func (b *box[T]) reset() { // want `\Qreset method may call os.Exit`
+	os.Exit(0)
+}
+
+func (a *app) resetBox(b *box[int]) { // want `\QresetBox method may call os.Exit`
+	b.reset()
+}
+
+func writeTo(buf *bytes.Buffer, s string) {
+	buf.WriteString(s)
+}
+
+func writeTwice(buf *bytes.Buffer) {
+	writeTo(buf, "a") // want `\QwriteTo writes to a buffer`
+	writeTo(buf, "b") // want `\QwriteTo writes to a buffer`
+	identity(buf)
+}
+
+func identity(buf *bytes.Buffer) *bytes.Buffer { return buf }
@@ -0,0 +1,85 @@
+package controlflow
+
+import (
+	"log"
+	"os"
+)
+
+func readClosed(name string) error {
+	f, err := os.Open(name)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	return nil
+}
+
+func readLeaked(name string) error {
+	f, err := os.Open(name) // want `\Qf may be left open`
+	if err != nil {
+		return err
+	}
+	if name == "" {
+		return nil
+	}
+	return f.Close()
+}
+
+func readClosedOnReturn(name string) error {
+	f, err := os.Open(name)
+	if err != nil {
+		return err
+	}
+	return f.Close()
+}
+
+func openFatal(name string) {
+	f, err := os.Open(name)
+	if err != nil {
+		log.Fatal(err)
+	}
+	f.Close()
+}
+
+func deadcode(x int) int {
+	if x == 0 {
+		return 0
+		println("after return") // want `\Qunreachable println`
+	}
+	if x == 1 {
+		panic("one")
+		println("after panic") // want `\Qunreachable println`
+	}
+	if x == 2 {
+		os.Exit(2)
+		println("after exit") // want `\Qunreachable println`
+	}
+	if false {
+		println("constant condition") // want `\Qunreachable println`
+	}
+	for {
+		if x > 10 {
+			break
+		}
+		x++
+	}
+	println("reachable")
+	for {
+		x++
+	}
+	println("after infinite loop") // want `\Qunreachable println`
+	f := func() {
+		println("inside closure") // want `\Qunreachable println`
+	}
+	f()
+	return x
+}
+
+func reachableClosure() {
+	f := func() {
+		return
+		println("after closure return") // want `\Qunreachable println`
+	}
+	f()
+	println("reachable")
+}
@@ -0,0 +1,117 @@
+package controlflow
+
+import (
+	"errors"
+	"sync"
+)
+
+type cache struct {
+	mu   sync.Mutex
+	data map[string]int
+}
+
+func (c *cache) getDeferred(k string) int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.data[k]
+}
+
+func (c *cache) getExplicit(k string) int {
+	c.mu.Lock()
+	v := c.data[k]
+	c.mu.Unlock()
+	return v
+}
+
+func (c *cache) getEarlyReturn(k string) (int, error) {
+	c.mu.Lock() // want `\Qc.mu is not unlocked on every path`
+	v, ok := c.data[k]
+	if !ok {
+		return 0, errors.New("not found")
+	}
+	c.mu.Unlock()
+	return v, nil
+}
+
+func (c *cache) getBothBranches(k string) (int, error) {
+	c.mu.Lock()
+	v, ok := c.data[k]
+	if !ok {
+		c.mu.Unlock()
+		return 0, errors.New("not found")
+	}
+	c.mu.Unlock()
+	return v, nil
+}
+
+func (c *cache) getPanic(k string) int {
+	c.mu.Lock()
+	v, ok := c.data[k]
+	if !ok {
+		panic("not found")
+	}
+	c.mu.Unlock()
+	return v
+}
+
+func (c *cache) loop(keys []string) {
+	for _, k := range keys {
+		c.mu.Lock() // want `\Qc.mu is not unlocked on every path`
+		if k == "" {
+			break
+		}
+		c.data[k]++
+		c.mu.Unlock()
+	}
+}
+
+func (c *cache) loopContinue(keys []string) {
+	for _, k := range keys {
+		c.mu.Lock()
+		if k == "" {
+			c.mu.Unlock()
+			continue
+		}
+		c.data[k]++
+		c.mu.Unlock()
+	}
+}
+
+func (c *cache) switchUnlock(k string) int {
+	c.mu.Lock()
+	switch k {
+	case "a":
+		c.mu.Unlock()
+		return 1
+	case "b":
+		c.mu.Unlock()
+		return 2
+	default:
+		c.mu.Unlock()
+	}
+	return 0
+}
+
+func (c *cache) switchNoDefault(k string) int {
+	c.mu.Lock() // want `\Qc.mu is not unlocked on every path`
+	switch k {
+	case "a":
+		c.mu.Unlock()
+		return 1
+	}
+	return 0
+}
+
+func (c *cache) closure() func() {
+	return func() {
+		c.mu.Lock() // want `\Qc.mu is not unlocked on every path`
+		c.data["x"]++
+	}
+}
+
+func (c *cache) unlockInClosure() {
+	c.mu.Lock() // want `\Qc.mu is not unlocked on every path`
+	defer func() {
+		c.mu.Unlock()
+	}()
+}
@@ -0,0 +1,29 @@
+//go:build ignore
+// +build ignore
+
+package gorules
+
+import (
+	"github.com/quasilyte/go-ruleguard/dsl"
+)
+
+func lockWithoutUnlock(m dsl.Matcher) {
+	m.Match(`$mu.Lock()`).
+		Where(!m["$$"].AlwaysFollowedBy(`$mu.Unlock()`) &&
+			!m["$$"].AlwaysFollowedBy(`defer $mu.Unlock()`)).
+		Report(`$mu is not unlocked on every path`)
+}
+
+func openWithoutClose(m dsl.Matcher) {
+	m.Match(`$f, $err := os.Open($_); if $err != nil { $*_ }`).
+		Where(!m["$$"].AlwaysFollowedBy(`$f.Close()`) &&
+			!m["$$"].AlwaysFollowedBy(`defer $f.Close()`) &&
+			!m["$$"].AlwaysFollowedBy(`return $f.Close()`)).
+		Report(`$f may be left open`)
+}
+
+func deadPrint(m dsl.Matcher) {
+	m.Match(`println($*_)`).
+		Where(m.Deadcode()).
+		Report(`unreachable println`)
+}
@@ -0,0 +1,104 @@
+package definedby
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+)
+
+func process(ctx context.Context, x int) {}
+
+func contexts(parent context.Context) {
+	ctx := context.Background()
+	process(ctx, 1) // want `\Quse the caller context instead of ctx`
+
+	var ctx2 = context.Background()
+	process(ctx2, 2) // want `\Quse the caller context instead of ctx2`
+
+	ctx3, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	process(ctx3, 3)
+
+	process(parent, 4)
+	process(context.Background(), 5)
+
+	ctx4 := context.Background()
+	if parent != nil {
+		ctx4 = parent
+	}
+	process(ctx4, 6)
+
+	var ctx5 context.Context
+	ctx5 = context.Background()
+	process(ctx5, 7)
+
+	ctx6 := context.Background()
+	withPointer(&ctx6)
+	process(ctx6, 8)
+
+	ctx7 := context.Background()
+	func() {
+		ctx7 = parent
+	}()
+	process(ctx7, 9)
+
+	ctx8 := context.TODO()
+	process(ctx8, 10)
+}
+
+func withPointer(ctx *context.Context) {}
+
+type config struct{}
+
+func decode(data []byte) error {
+	var c config
+	err := json.Unmarshal(data, &c)
+	if err != nil {
+		return err // want `\Qwrap the err json error`
+	}
+	return nil
+}
+
+func decodeReassigned(data []byte) error {
+	var c config
+	err := json.Unmarshal(data, &c)
+	if err != nil {
+		return err
+	}
+	_, err = json.Marshal(c)
+	return err
+}
+
+func decodeRedeclared(data []byte) error {
+	var c config
+	err := json.Unmarshal(data, &c)
+	if err != nil {
+		return err
+	}
+	b, err := json.Marshal(c)
+	_ = b
+	return err
+}
+
+func decodeShadowed(data []byte) error {
+	var c config
+	if err := json.Unmarshal(data, &c); err != nil {
+		return err // want `\Qwrap the err json error`
+	}
+	return nil
+}
+
+func files() {
+	f, err := os.Open("a.txt")
+	if err != nil {
+		return
+	}
+	f.Write(nil) // want `\Qf is opened in read-only mode`
+
+	f2, _ := os.Create("b.txt")
+	f2.Write(nil)
+
+	var f3 *os.File
+	f3, _ = os.Open("c.txt")
+	f3.Write(nil)
+}
@@ -0,0 +1,26 @@
+//go:build ignore
+// +build ignore
+
+package gorules
+
+import (
+	"github.com/quasilyte/go-ruleguard/dsl"
+)
+
+func backgroundContext(m dsl.Matcher) {
+	m.Match(`$f($ctx, $*_)`).
+		Where(m["ctx"].Type.Is(`context.Context`) && m["ctx"].Object.DefinedBy(`context.Background()`)).
+		Report(`use the caller context instead of $ctx`)
+}
+
+func unmarshalError(m dsl.Matcher) {
+	m.Match(`return $err`).
+		Where(m["err"].Object.DefinedBy(`json.Unmarshal($_, $_)`)).
+		Report(`wrap the $err json error`)
+}
+
+func readOnlyFileWrite(m dsl.Matcher) {
+	m.Match(`$f.Write($_)`).
+		Where(m["f"].Object.DefinedBy(`os.Open($_)`)).
+		Report(`$f is opened in read-only mode`)
+}
@@ -5,9 +5,11 @@ package gorules
 
 import (
 	"fmt"
+	"strconv"
 	"strings"
 
 	"github.com/quasilyte/go-ruleguard/dsl"
+	"github.com/quasilyte/go-ruleguard/dsl/ast"
 	"github.com/quasilyte/go-ruleguard/dsl/types"
 )
 
@@ -52,6 +54,19 @@ func reportTypesIdentical(ctx *dsl.DoContext) {
 	ctx.SetReport(fmt.Sprintf("%v", types.Identical(xtype, ytype)))
 }
 
+func reportValue(ctx *dsl.DoContext) {
+	ctx.SetReport(ctx.Var("x").Value().ExactString())
+}
+
+func reportNumArgs(ctx *dsl.DoContext) {
+	call := ast.AsCallExpr(ctx.Var("x").Node())
+	if call == nil {
+		ctx.SetReport("not a call")
+		return
+	}
+	ctx.SetReport(strconv.Itoa(call.NumArgs()) + " args")
+}
+
 func testRules(m dsl.Matcher) {
 	m.Match(`test("custom report")`).
 		Do(reportHello)
@@ -73,4 +88,10 @@ func testRules(m dsl.Matcher) {
 
 	m.Match(`test("types identical", $x, $y)`).
 		Do(reportTypesIdentical)
+
+	m.Match(`test("report value", $x)`).
+		Do(reportValue)
+
+	m.Match(`test("report num args", $x)`).
+		Do(reportNumArgs)
 }
@@ -25,6 +25,16 @@ func Example() {
 	test("types identical", x, x)   // want `true`
 	test("types identical", x, &x)  // want `false`
 	test("types identical", 1, 1.5) // want `false`
+
+	test("report value", 10+5)    // want `\Q15`
+	test("report value", "a"+"b") // want `\Q"ab"`
+	test("report value", x)       // want `\Qunknown`
+
+	test("report num args", f(1, 2)) // want `\Q2 args`
+	test("report num args", f())     // want `\Q0 args`
+	test("report num args", x)       // want `\Qnot a call`
 }
 
+func f(args ...int) int { return 0 }
+
 func test(args ...interface{}) {}
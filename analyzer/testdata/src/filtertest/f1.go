@@ -219,7 +219,7 @@ func detectType() {
 	{
 		var s fmt.Stringer
 
-		typeTest(s, "is interface")
+		typeTest(s, "is interface")                // want `true`
 		typeTest(interface{}(nil), "is interface") // want `true`
 		typeTest(implementsAll{}, "is interface")
 		typeTest(&implementsAll{}, "is interface")
@@ -234,6 +234,52 @@ func detectType() {
 		typeTest("", "underlying is interface")
 	}
 
+	{
+		var closer io.Closer
+		var rc io.ReadCloser
+		var rwc io.ReadWriteCloser
+		var s fmt.Stringer
+		var custom interface {
+			io.ReadCloser
+			Name() string
+		}
+		var literal interface {
+			Read([]byte) (int, error)
+			Close() error
+		}
+
+		typeTest(closer, "has Close() error")  // want `true`
+		typeTest(rc, "has Close() error")      // want `true`
+		typeTest(custom, "has Close() error")  // want `true`
+		typeTest(literal, "has Close() error") // want `true`
+		typeTest(s, "has Close() error")
+		typeTest(implementsAll{}, "has Close() error")
+
+		typeTest(closer, "is io.Closer like") // want `true`
+		typeTest(rc, "is io.Closer like")
+		typeTest(literal, "is io.Closer like")
+
+		typeTest(rc, "embeds io.Reader")     // want `true`
+		typeTest(rwc, "embeds io.Reader")    // want `true`
+		typeTest(custom, "embeds io.Reader") // want `true`
+		typeTest(literal, "embeds io.Reader")
+		typeTest(closer, "embeds io.Reader")
+
+		type RC interface {
+			io.ReadCloser
+			Name() string
+		}
+		var userRC RC
+		var closers []io.Closer
+		typeTest(closer, "is Close() error interface")  // want `true`
+		typeTest(rc, "is Close() error interface")      // want `true`
+		typeTest(userRC, "is Close() error interface")  // want `true`
+		typeTest(literal, "is Close() error interface") // want `true`
+		typeTest(s, "is Close() error interface")
+		typeTest(closers, "is Close() error interface")
+		typeTest(implementsAll{}, "is Close() error interface")
+	}
+
 	{
 		type withNamedTime struct {
 			x int
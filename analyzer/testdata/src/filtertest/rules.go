@@ -200,6 +200,22 @@ func testRules(m dsl.Matcher) {
 		Where(m["x"].Type.Underlying().Is(`interface{ $*_ }`)).
 		Report(`true`)
 
+	m.Match(`typeTest($x, "has Close() error")`).
+		Where(m["x"].Type.Underlying().Is(`interface{ Close() error; $*_ }`)).
+		Report(`true`)
+
+	m.Match(`typeTest($x, "is io.Closer like")`).
+		Where(m["x"].Type.Underlying().Is(`interface{ Close() error }`)).
+		Report(`true`)
+
+	m.Match(`typeTest($x, "embeds io.Reader")`).
+		Where(m["x"].Type.Underlying().Is(`interface{ io.Reader; $*_ }`)).
+		Report(`true`)
+
+	m.Match(`typeTest($x, "is Close() error interface")`).
+		Where(m["x"].Type.Is(`interface{ Close() error; $*_ }`)).
+		Report(`true`)
+
 	m.Match(`textTest("", "root text test")`).
 		Where(m["$$"].Text == `textTest("", "root text test")`).
 		Report(`true`)
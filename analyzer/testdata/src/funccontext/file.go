@@ -0,0 +1,64 @@
+package funccontext
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+func Exported() error {
+	panic("bad") // want `\Qexported function returns an error, don't panic`
+}
+
+func unexported() error {
+	panic("ok")
+}
+
+func ExportedNoError() int {
+	panic("ok")
+}
+
+func ExportedMultiResult() (int, error) {
+	f := func() error {
+		panic("inside a closure") // want `\Qexported function returns an error, don't panic`
+	}
+	return 0, f()
+}
+
+var _ = func() error {
+	panic("not inside a function declaration")
+}
+
+type Point struct{ X, Y int }
+
+func (p Point) String() string {
+	return fmt.Sprint(p.X) // want `\Qfmt.Sprint inside String() method`
+}
+
+func String() string {
+	return fmt.Sprint(10)
+}
+
+type Buffer struct{ data []byte }
+
+func (b *Buffer) Grow(n int) {
+	b.data = append(b.data, make([]byte, n)...) // want `\Qallocation inside *Buffer method`
+}
+
+func (b Buffer) Copy() []byte {
+	return append(make([]byte, 0), b.data...)
+}
+
+func newBuffer(n int) *Buffer {
+	return &Buffer{data: make([]byte, n)}
+}
+
+func TestNotInTestFile() {
+	time.Sleep(time.Second)
+}
+
+func deprecatedHelper() {} // want `\Qdeprecated function`
+
+func helper() {}
+
+var _ = errors.New
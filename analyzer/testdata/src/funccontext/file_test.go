@@ -0,0 +1,22 @@
+package funccontext
+
+import (
+	"testing"
+	"time"
+)
+
+func TestSleep(t *testing.T) {
+	time.Sleep(time.Millisecond) // want `\Qtime.Sleep in test function`
+}
+
+func Testsleep(t *testing.T) {
+	time.Sleep(time.Millisecond)
+}
+
+func BenchmarkSleep(b *testing.B) {
+	time.Sleep(time.Millisecond) // want `\Qtime.Sleep in test function`
+}
+
+func sleepHelper() {
+	time.Sleep(time.Millisecond)
+}
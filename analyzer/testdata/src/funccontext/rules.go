@@ -0,0 +1,40 @@
+//go:build ignore
+// +build ignore
+
+package gorules
+
+import (
+	"github.com/quasilyte/go-ruleguard/dsl"
+)
+
+func panicInErrorFunc(m dsl.Matcher) {
+	m.Match(`panic($_)`).
+		Where(m.Func().Name.Matches(`^[A-Z]`) && m.Func().Results.Contains("error")).
+		Report(`exported function returns an error, don't panic`)
+}
+
+func stringerPrint(m dsl.Matcher) {
+	m.Match(`fmt.Sprint($x)`).
+		Where(m.Func().IsMethod && m.Func().Name.Matches(`^String$`)).
+		Report(`fmt.Sprint inside String() method`)
+}
+
+func bufferMethodAlloc(m dsl.Matcher) {
+	m.Import(`funccontext`)
+
+	m.Match(`make([]byte, $_)`).
+		Where(m.Func().Receiver.Type.Is(`*funccontext.Buffer`)).
+		Report(`allocation inside *Buffer method`)
+}
+
+func sleepInTest(m dsl.Matcher) {
+	m.Match(`time.Sleep($_)`).
+		Where(m.Func().IsTest).
+		Report(`time.Sleep in test function`)
+}
+
+func funcDeclName(m dsl.Matcher) {
+	m.Match(`func $_($*_) { $*_ }`).
+		Where(m.Func().Name.Matches(`^deprecated`)).
+		Report(`deprecated function`)
+}
@@ -18,3 +18,33 @@ func largeLoopCopy(m dsl.Matcher) {
 		Where(m["v"].Type.Size > 512).
 		Report(`loop copies large value each iteration`)
 }
+
+func atomicPointerLoad(m dsl.Matcher) {
+	m.Match(`$p.Load()`).
+		Where(m["p"].Type.Is(`atomic.Pointer[$_]`)).
+		Report(`$p is a generic atomic pointer`)
+}
+
+func stringContainerSet(m dsl.Matcher) {
+	m.Import(`generics`)
+	m.Match(`$x.set($_)`).
+		Where(m["x"].Type.Is(`*generics.myType[string]`)).
+		Report(`$x holds a string`)
+}
+
+func samePairTypes(m dsl.Matcher) {
+	m.Import(`generics`)
+	m.Match(`$x := $_`).
+		Where(m["x"].Type.Is(`generics.pair[$t, $t]`)).
+		Report(`$x pair has identical types`)
+}
+
+func typeParamCompare(m dsl.Matcher) {
+	m.Match(`$x == $_`).
+		Where(m["x"].Type.Is(`typeparam[comparable]`)).
+		Report(`comparing a comparable type param`)
+
+	m.Match(`$x < $_`).
+		Where(m["x"].Type.Is(`typeparam[interface{ $*_ }]`) && !m["x"].Type.Is(`typeparam[any]`)).
+		Report(`comparing a constrained type param`)
+}
@@ -1,5 +1,7 @@
 package main
 
+import "sync/atomic"
+
 type myType[T any] struct {
 	value T
 }
@@ -15,3 +17,36 @@ func Map[T, R any](s []T, f func(T) R) []R {
 	}
 	return result
 }
+
+type pair[A, B any] struct {
+	a A
+	b B
+}
+
+func genericsTypes() {
+	var p atomic.Pointer[int]
+	_ = p.Load() // want `\Qp is a generic atomic pointer`
+	var v atomic.Value
+	_ = v.Load()
+
+	s := &myType[string]{}
+	s.set("") // want `\Qs holds a string`
+	i := &myType[int]{}
+	i.set(0)
+
+	p1 := pair[int, int]{} // want `\Qp1 pair has identical types`
+	p2 := pair[int, string]{}
+	_, _ = p1, p2
+}
+
+func equal[T comparable](x, y T) bool {
+	return x == y // want `\Qcomparing a comparable type param`
+}
+
+func less[T ~int | ~string](x, y T) bool {
+	return x < y // want `\Qcomparing a constrained type param`
+}
+
+func notTypeParam(x, y int) bool {
+	return x == y || x < y
+}
@@ -0,0 +1,16 @@
+package pkgscope
+
+import "os"
+
+func init() {} // want `\Qinit() is defined in several package files`
+
+type Point struct{ X, Y int } // want `\QPoint and its String() method are defined in different files`
+
+type Color int
+
+func (c Color) String() string { return "color" }
+
+func exitA() {
+	os.Exit(1) // want `\Qos.Exit(1) is called several times in the package`
+	os.Exit(0)
+}
@@ -0,0 +1,14 @@
+package pkgscope
+
+import (
+	"fmt"
+	"os"
+)
+
+func init() {} // want `\Qinit() is defined in several package files`
+
+func (p *Point) String() string { return fmt.Sprint(p.X, p.Y) } // want `\QPoint and its String() method are defined in different files`
+
+func exitB() {
+	os.Exit(2) // want `\Qos.Exit(2) is called several times in the package`
+}
@@ -0,0 +1,29 @@
+//go:build ignore
+// +build ignore
+
+package gorules
+
+import (
+	"github.com/quasilyte/go-ruleguard/dsl"
+)
+
+func initInSeveralFiles(m dsl.Matcher) {
+	m.Match(`func init() { $*_ }`).
+		PackageWhere(m.Matches().Files > 1).
+		Report(`init() is defined in several package files`)
+}
+
+func stringerInAnotherFile(m dsl.Matcher) {
+	m.Match(`type $t $_`,
+		`func ($_ $t) String() string { $*_ }`,
+		`func ($_ *$t) String() string { $*_ }`).
+		PackageWhere(m.Matches().Files > 1, m["t"]).
+		Report(`$t and its String() method are defined in different files`)
+}
+
+func repeatedExit(m dsl.Matcher) {
+	m.Match(`os.Exit($code)`).
+		Where(m["code"].Text != "0").
+		PackageWhere(m.Matches().Count >= 2 && !(m.Matches().Count > 3)).
+		Report(`os.Exit($code) is called several times in the package`)
+}
@@ -4,7 +4,13 @@
 package gorules
 
 import (
+	"regexp"
+	"strings"
+	"unicode"
+
 	"github.com/quasilyte/go-ruleguard/dsl"
+	"github.com/quasilyte/go-ruleguard/dsl/ast"
+	"github.com/quasilyte/go-ruleguard/dsl/constant"
 	"github.com/quasilyte/go-ruleguard/dsl/types"
 )
 
@@ -130,6 +136,194 @@ func isIntSlice(ctx *dsl.VarFilterContext) bool {
 	return types.Identical(ctx.Type, intSlice)
 }
 
+func derefStruct(typ types.Type) *types.Struct {
+	ptr := types.AsPointer(typ)
+	if ptr != nil {
+		return types.AsStruct(ptr.Elem().Underlying())
+	}
+	return types.AsStruct(typ.Underlying())
+}
+
+func hasUntaggedExportedField(ctx *dsl.VarFilterContext) bool {
+	asStruct := derefStruct(ctx.Type)
+	if asStruct == nil {
+		return false
+	}
+	i := 0
+	for i < asStruct.NumFields() {
+		field := asStruct.Field(i)
+		if field.Exported() && !field.Embedded() && types.StructTagGet(asStruct.Tag(i), `json`) == "" {
+			return true
+		}
+		i++
+	}
+	return false
+}
+
+func hasTaggedUnexportedField(ctx *dsl.VarFilterContext) bool {
+	asStruct := derefStruct(ctx.Type)
+	if asStruct == nil {
+		return false
+	}
+	i := 0
+	for i < asStruct.NumFields() {
+		if !asStruct.Field(i).Exported() && types.StructTagGet(asStruct.Tag(i), `json`) != "" {
+			return true
+		}
+		i++
+	}
+	return false
+}
+
+func hasIDField(ctx *dsl.VarFilterContext) bool {
+	asStruct := derefStruct(ctx.Type)
+	if asStruct == nil {
+		return false
+	}
+	i := 0
+	for i < asStruct.NumFields() {
+		if asStruct.Field(i).Name() == `ID` {
+			return true
+		}
+		i++
+	}
+	return false
+}
+
+func isSyncType(ctx *dsl.VarFilterContext) bool {
+	named := types.AsNamed(ctx.Type)
+	if named == nil {
+		return false
+	}
+	pkg := named.Obj().Pkg()
+	return pkg != nil && pkg.Path() == `sync`
+}
+
+func hasExportedMethods(ctx *dsl.VarFilterContext) bool {
+	named := types.AsNamed(ctx.Type)
+	if named == nil {
+		return false
+	}
+	i := 0
+	for i < named.NumMethods() {
+		if named.Method(i).Exported() {
+			return true
+		}
+		i++
+	}
+	return false
+}
+
+func isGenericComparable(ctx *dsl.VarFilterContext) bool {
+	named := types.AsNamed(ctx.Type)
+	if named == nil {
+		return false
+	}
+	params := named.TypeParams()
+	if params.Len() == 0 {
+		return false
+	}
+	return params.At(0).Constraint().String() == `comparable`
+}
+
+func isTypeParam(ctx *dsl.VarFilterContext) bool {
+	return types.AsTypeParam(ctx.Type) != nil
+}
+
+func hasAcronymName(ctx *dsl.VarFilterContext) bool {
+	parts := strings.Split(ctx.Type.String(), ".")
+	name := parts[len(parts)-1]
+	if !unicode.IsUpper(rune(name[0])) {
+		return false
+	}
+	matched, err := regexp.MatchString(`^[A-Z]{2,}[a-z]`, name)
+	return err == nil && matched
+}
+
+func isTwoArgCall(ctx *dsl.VarFilterContext) bool {
+	call := ast.AsCallExpr(ctx.Node)
+	return call != nil && call.NumArgs() == 2
+}
+
+func isSpreadCall(ctx *dsl.VarFilterContext) bool {
+	call := ast.AsCallExpr(ctx.Node)
+	return call != nil && call.HasEllipsis()
+}
+
+func isStringMethodCall(ctx *dsl.VarFilterContext) bool {
+	call := ast.AsCallExpr(ctx.Node)
+	if call == nil {
+		return false
+	}
+	sel := ast.AsSelectorExpr(call.Fun())
+	return sel != nil && sel.Sel().Name() == "String"
+}
+
+func callsLocalFunc(ctx *dsl.VarFilterContext) bool {
+	call := ast.AsCallExpr(ctx.Node)
+	if call == nil {
+		return false
+	}
+	return ast.AsIdent(call.Fun()) != nil
+}
+
+func isHexLiteral(ctx *dsl.VarFilterContext) bool {
+	lit := ast.AsBasicLit(ast.Unparen(ctx.Node))
+	return lit != nil && lit.Kind() == "INT" && strings.HasPrefix(lit.Value(), "0x")
+}
+
+func isEmptyCompositeLit(ctx *dsl.VarFilterContext) bool {
+	lit := ast.AsCompositeLit(ctx.Node)
+	return lit != nil && lit.Type() != nil && lit.NumElts() == 0
+}
+
+func hasElidedEltType(ctx *dsl.VarFilterContext) bool {
+	lit := ast.AsCompositeLit(ctx.Node)
+	if lit == nil || lit.NumElts() == 0 {
+		return false
+	}
+	elt := ast.AsCompositeLit(lit.Elt(0))
+	return elt != nil && elt.Type() == nil
+}
+
+func isNoResultFuncLit(ctx *dsl.VarFilterContext) bool {
+	fn := ast.AsFuncLit(ctx.Node)
+	return fn != nil && fn.NumResults() == 0
+}
+
+func isBinaryFuncLit(ctx *dsl.VarFilterContext) bool {
+	fn := ast.AsFuncLit(ctx.Node)
+	return fn != nil && fn.NumParams() == 2 && fn.NumStmts() == 1
+}
+
+func isLargeIntConst(ctx *dsl.VarFilterContext) bool {
+	if ctx.Value == nil {
+		return false
+	}
+	v, exact := constant.IntVal(ctx.Value)
+	return exact && v > 10
+}
+
+func isFooConst(ctx *dsl.VarFilterContext) bool {
+	return constant.StringVal(ctx.Value) == "foo"
+}
+
+func isTrueConst(ctx *dsl.VarFilterContext) bool {
+	return constant.BoolVal(ctx.Value)
+}
+
+func isFractionalConst(ctx *dsl.VarFilterContext) bool {
+	if ctx.Value.Kind() != constant.Float {
+		return false
+	}
+	v, _ := constant.Float64Val(ctx.Value)
+	return v != float64(int(v))
+}
+
+func isNonConst(ctx *dsl.VarFilterContext) bool {
+	return ctx.Value.Kind() == constant.Unknown
+}
+
 func testRules(m dsl.Matcher) {
 	m.Match(`test($x, "is [3]int")`).
 		Where(m["x"].Filter(isIntArray3)).
@@ -187,4 +381,92 @@ func testRules(m dsl.Matcher) {
 	m.Match(`test($x, "embeds a mutex")`).
 		Where(m["x"].Filter(embedsMutex)).
 		Report(`true`)
+
+	m.Match(`test($x, "has untagged exported field")`).
+		Where(m["x"].Filter(hasUntaggedExportedField)).
+		Report(`true`)
+
+	m.Match(`test($x, "has tagged unexported field")`).
+		Where(m["x"].Filter(hasTaggedUnexportedField)).
+		Report(`true`)
+
+	m.Match(`test($x, "has ID field")`).
+		Where(m["x"].Filter(hasIDField)).
+		Report(`true`)
+
+	m.Match(`test($x, "is sync type")`).
+		Where(m["x"].Filter(isSyncType)).
+		Report(`true`)
+
+	m.Match(`test($x, "has exported methods")`).
+		Where(m["x"].Filter(hasExportedMethods)).
+		Report(`true`)
+
+	m.Match(`test($x, "is generic with comparable param")`).
+		Where(m["x"].Filter(isGenericComparable)).
+		Report(`true`)
+
+	m.Match(`test($x, "is type param")`).
+		Where(m["x"].Filter(isTypeParam)).
+		Report(`true`)
+
+	m.Match(`test($x, "has acronym name")`).
+		Where(m["x"].Filter(hasAcronymName)).
+		Report(`true`)
+
+	m.Match(`test($x, "is 2-arg call")`).
+		Where(m["x"].Filter(isTwoArgCall)).
+		Report(`true`)
+
+	m.Match(`test($x, "is spread call")`).
+		Where(m["x"].Filter(isSpreadCall)).
+		Report(`true`)
+
+	m.Match(`test($x, "is String method call")`).
+		Where(m["x"].Filter(isStringMethodCall)).
+		Report(`true`)
+
+	m.Match(`test($x, "calls local func")`).
+		Where(m["x"].Filter(callsLocalFunc)).
+		Report(`true`)
+
+	m.Match(`test($x, "is hex literal")`).
+		Where(m["x"].Filter(isHexLiteral)).
+		Report(`true`)
+
+	m.Match(`test($x, "is empty composite lit")`).
+		Where(m["x"].Filter(isEmptyCompositeLit)).
+		Report(`true`)
+
+	m.Match(`test($x, "has elided elt type")`).
+		Where(m["x"].Filter(hasElidedEltType)).
+		Report(`true`)
+
+	m.Match(`test($x, "is no-result func lit")`).
+		Where(m["x"].Filter(isNoResultFuncLit)).
+		Report(`true`)
+
+	m.Match(`test($x, "is binary func lit")`).
+		Where(m["x"].Filter(isBinaryFuncLit)).
+		Report(`true`)
+
+	m.Match(`test($x, "is large int const")`).
+		Where(m["x"].Filter(isLargeIntConst)).
+		Report(`true`)
+
+	m.Match(`test($x, "is foo const")`).
+		Where(m["x"].Filter(isFooConst)).
+		Report(`true`)
+
+	m.Match(`test($x, "is true const")`).
+		Where(m["x"].Filter(isTrueConst)).
+		Report(`true`)
+
+	m.Match(`test($x, "is fractional const")`).
+		Where(m["x"].Filter(isFractionalConst)).
+		Report(`true`)
+
+	m.Match(`test($x, "is non-const")`).
+		Where(m["x"].Filter(isNonConst)).
+		Report(`true`)
 }
@@ -2,6 +2,7 @@ package quasigo
 
 import (
 	"fmt"
+	"strings"
 	"sync"
 )
 
@@ -111,10 +112,122 @@ func f() {
 	test(withNestedMutex{}, "embeds a mutex") // OK: not embedded
 	test(withoutMutex{}, "embeds a mutex")    // OK: no mutex at all
 	test(1, "embeds a mutex")                 // OK: not a struct
+
+	test(untaggedUser{}, "has untagged exported field")  // want `true`
+	test(&untaggedUser{}, "has untagged exported field") // want `true`
+	test(taggedUser{}, "has untagged exported field")
+	test(withEmbeddedMutex1{}, "has untagged exported field") // OK: embedded
+	test(1, "has untagged exported field")
+
+	test(taggedUser{}, "has tagged unexported field") // want `true`
+	test(untaggedUser{}, "has tagged unexported field")
+
+	test(taggedUser{}, "has ID field") // want `true`
+	test(untaggedUser{}, "has ID field")
+
+	test(sync.Mutex{}, "is sync type")     // want `true`
+	test(sync.WaitGroup{}, "is sync type") // want `true`
+	test(withMutex{}, "is sync type")
+	test(err, "is sync type") // OK: predeclared error has no package
+	test(0, "is sync type")
+
+	test(stringerByValue{}, "has exported methods") // want `true`
+	test(stringerByPtr{}, "has exported methods")   // want `true`
+	test(taggedUser{}, "has exported methods")      // OK: unexported method
+	test(withMutex{}, "has exported methods")
+
+	test(set[int]{}, "is generic with comparable param") // want `true`
+	test(box[int]{}, "is generic with comparable param")
+	test(taggedUser{}, "is generic with comparable param")
+
+	test(HTTPClient{}, "has acronym name") // want `true`
+	test(HttpClient{}, "has acronym name")
+	test(myString(""), "has acronym name")
+
+	var xs []interface{}
+	var sb strings.Builder
+	test(fmt.Sprint(1, 2), "is 2-arg call") // want `true`
+	test(fmt.Sprint(1), "is 2-arg call")
+	test(fmt.Sprint(xs...), "is 2-arg call")
+	test(i, "is 2-arg call")
+
+	test(fmt.Sprint(xs...), "is spread call") // want `true`
+	test(fmt.Sprint(xs), "is spread call")
+
+	test(sb.String(), "is String method call")       // want `true`
+	test(stringer.String(), "is String method call") // want `true`
+	test(err.Error(), "is String method call")
+	test(len(xs), "is String method call")
+
+	test(len(xs), "calls local func") // want `true`
+	test(sb.Len(), "calls local func")
+
+	test(0x10, "is hex literal")   // want `true`
+	test((0xff), "is hex literal") // want `true`
+	test(16, "is hex literal")
+	test("0x10", "is hex literal")
+
+	test([]int{}, "is empty composite lit")        // want `true`
+	test(withoutMutex{}, "is empty composite lit") // want `true`
+	test([]int{1}, "is empty composite lit")
+	test([][]int{{}}, "is empty composite lit")
+
+	test([][]int{{1}}, "has elided elt type")           // want `true`
+	test([]withoutMutex{{x: 1}}, "has elided elt type") // want `true`
+	test([][]int{[]int{1}}, "has elided elt type")
+	test([]int{1}, "has elided elt type")
+
+	test(func() {}, "is no-result func lit")      // want `true`
+	test(func(x int) {}, "is no-result func lit") // want `true`
+	test(func() int { return 0 }, "is no-result func lit")
+
+	test(func(x, y int) int { return x + y }, "is binary func lit")         // want `true`
+	test(func(x int, y string) bool { return false }, "is binary func lit") // want `true`
+	test(func(x int) int { return x }, "is binary func lit")
+	test(func(x, y int) {}, "is binary func lit")
+
+	const big = 100
+	test(11, "is large int const")      // want `true`
+	test(big, "is large int const")     // want `true`
+	test(20.0, "is large int const")    // want `true`
+	test(big/2+1, "is large int const") // want `true`
+	test(10, "is large int const")
+	test(20.5, "is large int const")
+	test(i, "is large int const")
+	test("foo", "is large int const")
+
+	test("foo", "is foo const")           // want `true`
+	test("f"+"oo", "is foo const")        // want `true`
+	test(myString("foo"), "is foo const") // want `true`
+	test("bar", "is foo const")
+	test(big, "is foo const")
+
+	test(true, "is true const")     // want `true`
+	test(big > 10, "is true const") // want `true`
+	test(false, "is true const")
+	test(i > 10, "is true const")
+
+	test(1.5, "is fractional const") // want `true`
+	test(1.0, "is fractional const")
+	test(1, "is fractional const")
+
+	test(i, "is non-const")        // want `true`
+	test(sb.Len(), "is non-const") // want `true`
+	test(big, "is non-const")
+	test(len("abc"), "is non-const")
+}
+
+func g[T any](x T) {
+	test(x, "is type param") // want `true`
+	test(0, "is type param")
 }
 
 type myString string
 
+type HTTPClient struct{}
+
+type HttpClient struct{}
+
 type myEmptyStruct struct{}
 
 type parseError struct{}
@@ -151,3 +264,20 @@ type withNestedMutex struct {
 type withoutMutex struct {
 	x int
 }
+
+type untaggedUser struct {
+	Name string
+	age  int
+}
+
+type taggedUser struct {
+	ID    int    `json:"id"`
+	Name  string `json:"name,omitempty"`
+	token string `json:"token"`
+}
+
+func (taggedUser) validate() bool { return true }
+
+type set[T comparable] map[T]struct{}
+
+type box[T any] struct{ value T }
@@ -0,0 +1,9 @@
+package quickfix
+
+import (
+	"fmt"
+)
+
+func constErrorf(x int) (error, error) {
+	return fmt.Errorf("bad value"), fmt.Errorf("bad value: %d", x) // want `\Qsuggestion: errors.New("bad value")`
+}
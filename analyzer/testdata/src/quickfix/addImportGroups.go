@@ -0,0 +1,12 @@
+package quickfix
+
+import (
+	"bytes"
+	"fmt"
+
+	"os"
+)
+
+func constErrorfGroups(b *bytes.Buffer) (error, *os.File) {
+	return fmt.Errorf("bad group"), nil // want `\Qsuggestion: errors.New("bad group")`
+}
@@ -0,0 +1,7 @@
+package quickfix
+
+import ("fmt"; "os")
+
+func constErrorfSingleLine() (error, *os.File) {
+	return fmt.Errorf("bad file"), nil // want `\Qsuggestion: errors.New("bad file")`
+}
@@ -0,0 +1,7 @@
+package quickfix
+
+import "strings"
+
+func swappedHasPrefix(s string) bool {
+	return strings.HasPrefix("http://", s) // want `\Qsuspicious strings.HasPrefix args order`
+}
@@ -0,0 +1,7 @@
+package quickfix
+
+import "bytes"
+
+func bytesCompare(a, b []byte) bool {
+	return bytes.Compare(a, b) == 0 // want `\Qsuggestion: bytes.Equal(a, b)`
+}
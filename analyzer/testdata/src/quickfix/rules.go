@@ -16,3 +16,23 @@ func writeString(m dsl.Matcher) {
 		Where(m["w"].Type.HasMethod(`io.StringWriter.WriteString`)).
 		Suggest(`$w.WriteString($s)`)
 }
+
+func bytesCompare(m dsl.Matcher) {
+	m.Match(`bytes.Compare($x, $y) == 0`).
+		SuggestLabeled(`use bytes.Equal`, `bytes.Equal($x, $y)`).
+		SuggestLabeled(`compare as strings`, `string($x) == string($y)`)
+}
+
+func swappedHasPrefix(m dsl.Matcher) {
+	m.Match(`strings.HasPrefix($prefix, $s)`).
+		Where(m["prefix"].Const && !m["s"].Const).
+		Report(`suspicious strings.HasPrefix args order`).
+		SuggestAt(m["prefix"], `$s`).
+		SuggestAt(m["s"], `$prefix`)
+}
+
+func constErrorf(m dsl.Matcher) {
+	m.Match(`fmt.Errorf($s)`).
+		Where(m["s"].Const).
+		Suggest(`errors.New($s)`)
+}
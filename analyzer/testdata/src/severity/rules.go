@@ -0,0 +1,25 @@
+//go:build ignore
+// +build ignore
+
+package gorules
+
+import "github.com/quasilyte/go-ruleguard/dsl"
+
+//doc:severity error
+func panicCall(m dsl.Matcher) {
+	m.Match(`panic($_)`).Report(`panic call`)
+}
+
+//doc:severity warning
+func printlnCall(m dsl.Matcher) {
+	m.Match(`println($*_)`).Report(`println call`)
+}
+
+//doc:severity info
+func printCall(m dsl.Matcher) {
+	m.Match(`print($*_)`).Report(`print call`)
+}
+
+func copyCall(m dsl.Matcher) {
+	m.Match(`copy($_, $_)`).Report(`copy call`)
+}
@@ -0,0 +1,8 @@
+package severity
+
+func f(dst, src []byte) {
+	println("a") // want `\Qprintln call`
+	print("b")
+	copy(dst, src) // want `\Qcopy call`
+	panic("c")     // want `\Qpanic call`
+}
@@ -0,0 +1,77 @@
+package siblings
+
+import (
+	"os"
+	"sync"
+)
+
+func openTest() {
+	f1, err := os.Open("a.txt") // want `\Qf1 is not closed with defer`
+	if err != nil {             // want `\Qerr is checked, but not used`
+		return
+	}
+	_ = f1
+
+	f2, err := os.Open("b.txt")
+	if err != nil { // want `\Qerr is checked, but not used`
+		return
+	}
+	defer f2.Close()
+
+	f3, err := os.Open("c.txt") // want `\Qf3 is not closed with defer`
+	if err == nil {
+		defer f3.Close()
+	}
+
+	f4, err := os.Open("d.txt") // want `\Qf4 is not closed with defer`
+	defer f2.Close()
+	_ = f4
+
+	{
+		f5, _ := os.Open("e.txt")
+		defer f5.Close()
+	}
+
+	switch {
+	case true:
+		f6, _ := os.Open("f.txt")
+		defer f6.Close()
+	}
+}
+
+func createTest() {
+	f, _ := os.Create("a.txt") // want `\Qclosed "a.txt" file`
+	defer f.Close()
+
+	_, _ = os.Create("b.txt")
+}
+
+func errorTest() error {
+	_, err1 := os.Stat("a")
+	if err1 != nil {
+		return err1
+	}
+	_, err2 := os.Stat("b")
+	if err2 != nil { // want `\Qerr2 is checked, but not used`
+		return err1
+	}
+	_, err3 := os.Stat("c")
+	if err3 != nil {
+		println(err3.Error())
+	}
+	return nil
+}
+
+func waitTest(xs []int) {
+	var wg1 sync.WaitGroup
+	wg1.Add(len(xs))
+	wg1.Wait()
+
+	var wg2 sync.WaitGroup
+	wg1.Add(len(xs))
+	wg2.Wait() // want `\Qwg2.Wait() without wg2.Add()`
+
+	var wg3 sync.WaitGroup
+	wg3.Wait() // want `\Qwg3.Wait() without wg3.Add()`
+	wg3.Add(1)
+}
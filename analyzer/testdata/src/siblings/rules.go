@@ -0,0 +1,32 @@
+//go:build ignore
+// +build ignore
+
+package gorules
+
+import (
+	"github.com/quasilyte/go-ruleguard/dsl"
+)
+
+func unclosedFile(m dsl.Matcher) {
+	m.Match(`$f, $err := os.Open($_)`).
+		Where(!m["$$"].FollowedBy(`defer $f.Close()`)).
+		Report(`$f is not closed with defer`)
+}
+
+func closedFile(m dsl.Matcher) {
+	m.Match(`os.Create($name)`).
+		Where(m["name"].FollowedBy(`defer $_.Close()`)).
+		Report(`closed $name file`)
+}
+
+func unusedError(m dsl.Matcher) {
+	m.Match(`if $err != nil { $*body }`).
+		Where(m["err"].Type.Is(`error`) && m["body"].NotContains(`$err`)).
+		Report(`$err is checked, but not used`)
+}
+
+func waitWithoutAdd(m dsl.Matcher) {
+	m.Match(`$wg.Wait()`).
+		Where(!m["$$"].PrecededBy(`$wg.Add($_)`)).
+		Report(`$wg.Wait() without $wg.Add()`)
+}
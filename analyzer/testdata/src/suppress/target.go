@@ -0,0 +1,49 @@
+package suppress
+
+func sameLine() {
+	panic("a")   //ruleguard:ignore panicCall
+	println("a") //ruleguard:ignore
+	println("b") //ruleguard:ignore panicCall,printlnCall
+	panic("b")   // want `\Qpanic call`
+}
+
+func otherGroup() {
+	panic("a") //ruleguard:ignore printlnCall // want `\Qpanic call` `\Qunused suppression directive`
+}
+
+//ruleguard:ignore printlnCall
+func wholeDecl() {
+	println("a")
+	if true {
+		println("b")
+	}
+	panic("a") // want `\Qpanic call`
+}
+
+// wholeDecl2 has a directive in the middle of its doc comment.
+//
+//ruleguard:ignore
+func wholeDecl2() {
+	println("a")
+	panic("a")
+}
+
+var (
+	//ruleguard:ignore panicCall
+	_ = func() int { panic("a") }
+
+	_ = func() int { panic("b") } // want `\Qpanic call`
+)
+
+func unused() {
+	//ruleguard:ignore printlnCall // want `\Qunused suppression directive`
+
+	//ruleguard:ignore // want `\Qunused suppression directive`
+
+	// Not reported: fooGroup is not loaded.
+	//ruleguard:ignore fooGroup
+}
+
+func notDirective() {
+	println("a") //ruleguard:ignorepanicCall // want `\Qprintln call`
+}
@@ -0,0 +1,111 @@
+package main
+
+import (
+	"errors"
+	"go/format"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestFixOverlappingEdits(t *testing.T) {
+	dir := t.TempDir()
+	ruleguardBin := filepath.Join(dir, "ruleguard.exe")
+	out, err := exec.Command("go", "build", "-o", ruleguardBin, ".").CombinedOutput()
+	if err != nil {
+		t.Fatalf("build ruleguard: %v: %s", err, out)
+	}
+
+	// Both rules match the `b == true` inside the return statement,
+	// so their edits overlap. The second rule replacement is not gofmt'd.
+	files := map[string]string{
+		"go.mod": "module example.com/target\n\ngo 1.22\n",
+		"rules.go": `//go:build ignore
+
+package gorules
+
+import "github.com/quasilyte/go-ruleguard/dsl"
+
+func boolCmp(m dsl.Matcher) {
+	m.Match(` + "`$x == true`" + `).Suggest(` + "`$x`" + `)
+	m.Match(` + "`!($x == true)`" + `).Suggest(` + "`$x==false`" + `)
+}
+`,
+		"target.go": `package target
+
+func f(b bool) bool {
+	if b == true {
+		return !(b == true)
+	}
+	return false
+}
+`,
+	}
+	targetDir := filepath.Join(dir, "target")
+	if err := os.Mkdir(targetDir, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	for name, data := range files {
+		if err := os.WriteFile(filepath.Join(targetDir, name), []byte(data), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	runRuleguard := func(args ...string) string {
+		cmd := exec.Command(ruleguardBin, append([]string{"-rules", "rules.go"}, args...)...)
+		cmd.Dir = targetDir
+		out, err := cmd.CombinedOutput()
+		var exitErr *exec.ExitError
+		if err != nil && !errors.As(err, &exitErr) {
+			t.Fatalf("run ruleguard: %v", err)
+		}
+		return string(out)
+	}
+
+	// -diff implies -fix, but doesn't update the files.
+	diffOutput := runRuleguard("-diff", ".")
+	if !strings.Contains(diffOutput, "+\t\treturn b == false\n") {
+		t.Fatalf("unexpected -diff output:\n%s", diffOutput)
+	}
+	for i := 0; i < 5; i++ {
+		if have := runRuleguard("-diff", "."); have != diffOutput {
+			t.Fatalf("-diff output is not deterministic:\n%s\nvs\n%s", diffOutput, have)
+		}
+	}
+	data, err := os.ReadFile(filepath.Join(targetDir, "target.go"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(data) != files["target.go"] {
+		t.Fatalf("-diff modified the target file:\n%s", data)
+	}
+
+	runRuleguard("-fix", ".")
+	data, err = os.ReadFile(filepath.Join(targetDir, "target.go"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := `package target
+
+func f(b bool) bool {
+	if b {
+		return b == false
+	}
+	return false
+}
+`
+	if diff := cmp.Diff(want, string(data)); diff != "" {
+		t.Errorf("fixed file mismatch (-want +have):\n%s", diff)
+	}
+	formatted, err := format.Source(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(formatted) != string(data) {
+		t.Errorf("fixed file is not gofmt'd:\n%s", data)
+	}
+}
@@ -0,0 +1,109 @@
+package main
+
+import (
+	"encoding/json"
+	"go/token"
+	"io"
+
+	"github.com/quasilyte/go-ruleguard/ruleguard"
+)
+
+// jsonReport is a -format=json output.
+//
+// Unlike the x/tools -json output, it includes the rule group
+// metadata and doesn't depend on the package structure.
+type jsonReport struct {
+	Groups   []jsonGroup   `json:"groups"`
+	Findings []jsonFinding `json:"findings"`
+}
+
+type jsonGroup struct {
+	Name      string   `json:"name"`
+	RulesFile string   `json:"rulesFile"`
+	Line      int      `json:"line"`
+	Severity  string   `json:"severity"`
+	Summary   string   `json:"summary,omitempty"`
+	Before    string   `json:"before,omitempty"`
+	After     string   `json:"after,omitempty"`
+	Note      string   `json:"note,omitempty"`
+	Tags      []string `json:"tags,omitempty"`
+}
+
+type jsonFinding struct {
+	Group   string    `json:"group"`
+	Message string    `json:"message"`
+	From    jsonPos   `json:"from"`
+	To      jsonPos   `json:"to"`
+	Fixes   []jsonFix `json:"fixes,omitempty"`
+}
+
+type jsonPos struct {
+	File   string `json:"file"`
+	Line   int    `json:"line"`
+	Column int    `json:"column"`
+	Offset int    `json:"offset"`
+}
+
+type jsonFix struct {
+	Label string     `json:"label"`
+	Edits []jsonEdit `json:"edits"`
+}
+
+type jsonEdit struct {
+	From    jsonPos `json:"from"`
+	To      jsonPos `json:"to"`
+	NewText string  `json:"newText"`
+}
+
+func writeJSON(w io.Writer, groups []ruleguard.GoRuleGroup, diagnostics []ruleguard.Diagnostic) error {
+	report := jsonReport{
+		Groups:   make([]jsonGroup, 0, len(groups)),
+		Findings: make([]jsonFinding, 0, len(diagnostics)),
+	}
+	for _, g := range groups {
+		report.Groups = append(report.Groups, jsonGroup{
+			Name:      g.Name,
+			RulesFile: g.Filename,
+			Line:      g.Line,
+			Severity:  g.Severity.String(),
+			Summary:   g.DocSummary,
+			Before:    g.DocBefore,
+			After:     g.DocAfter,
+			Note:      g.DocNote,
+			Tags:      g.DocTags,
+		})
+	}
+	for _, d := range diagnostics {
+		result := jsonFinding{
+			Group:   d.Group,
+			Message: d.Message,
+			From:    newJSONPos(d.From),
+			To:      newJSONPos(d.To),
+		}
+		for _, s := range d.Suggestions {
+			resultFix := jsonFix{Label: s.Label}
+			for _, e := range s.Edits {
+				resultFix.Edits = append(resultFix.Edits, jsonEdit{
+					From:    newJSONPos(e.From),
+					To:      newJSONPos(e.To),
+					NewText: e.Replacement,
+				})
+			}
+			result.Fixes = append(result.Fixes, resultFix)
+		}
+		report.Findings = append(report.Findings, result)
+	}
+
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(report)
+}
+
+func newJSONPos(pos token.Position) jsonPos {
+	return jsonPos{
+		File:   relativePath(pos.Filename),
+		Line:   pos.Line,
+		Column: pos.Column,
+		Offset: pos.Offset,
+	}
+}
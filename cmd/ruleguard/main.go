@@ -1,10 +1,90 @@
 package main
 
 import (
+	"fmt"
+	"os"
+	"strings"
+
 	"github.com/quasilyte/go-ruleguard/analyzer"
 	"golang.org/x/tools/go/analysis/singlechecker"
 )
 
 func main() {
-	singlechecker.Main(analyzer.Analyzer)
+	flags, args := extractDriverFlags(os.Args[1:])
+	if flags.format == "" && !flags.stats {
+		// singlechecker.Main parses the os.Args.
+		os.Args = append(os.Args[:1], args...)
+		singlechecker.Main(analyzer.Analyzer)
+		return
+	}
+	if flags.fix {
+		fmt.Fprintf(os.Stderr, "ruleguard: -fix and -diff can't be combined with -format or -stats\n")
+		os.Exit(1)
+	}
+	os.Exit(runDriver(flags, args))
+}
+
+// driverFlags are handled by our own driver instead of the singlechecker.
+type driverFlags struct {
+	// format is a -format value: "sarif" or "json".
+	format string
+
+	// stats is set by -stats: print the per-rule stats table at exit.
+	// Unlike other driver flags, it's also passed to the analyzer.
+	stats bool
+
+	// fix is set if -fix or -diff are used.
+	// These are handled by the singlechecker, so they're
+	// left in args and can't be combined with our driver flags.
+	// The singlechecker ignores -diff without -fix, so -diff implies -fix.
+	fix bool
+}
+
+// extractDriverFlags removes the driverFlags-related flags from args.
+//
+// singlechecker.Main parses the command-line flags on its own,
+// so we need to handle our extra flags before it's called.
+func extractDriverFlags(args []string) (driverFlags, []string) {
+	var flags driverFlags
+	hasFix := false
+	hasDiff := false
+	rest := make([]string, 0, len(args)+1)
+	for i := 0; i < len(args); i++ {
+		arg := args[i]
+		if arg == "--" {
+			rest = append(rest, args[i:]...)
+			break
+		}
+		name := strings.TrimLeft(arg, "-")
+		if name == arg {
+			rest = append(rest, arg) // Not a flag
+			continue
+		}
+		switch {
+		case name == "format" && i+1 < len(args):
+			flags.format = args[i+1]
+			i++
+		case strings.HasPrefix(name, "format="):
+			flags.format = strings.TrimPrefix(name, "format=")
+		case name == "stats" || name == "stats=true":
+			flags.stats = true
+			rest = append(rest, arg)
+		case name == "fix" || name == "fix=true":
+			flags.fix = true
+			hasFix = true
+			rest = append(rest, arg)
+		case name == "diff" || name == "diff=true":
+			flags.fix = true
+			hasDiff = true
+			rest = append(rest, arg)
+		case name == "fix=false" || name == "diff=false":
+			// Explicitly disabled, it's a no-op.
+		default:
+			rest = append(rest, arg)
+		}
+	}
+	if hasDiff && !hasFix {
+		rest = append([]string{"-fix"}, rest...)
+	}
+	return flags, rest
 }
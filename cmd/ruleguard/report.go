@@ -0,0 +1,194 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"go/token"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/analysis/checker"
+	"golang.org/x/tools/go/packages"
+
+	"github.com/quasilyte/go-ruleguard/analyzer"
+	"github.com/quasilyte/go-ruleguard/ruleguard"
+)
+
+// runDriver is a custom driver for the driverFlags-related modes.
+// It runs the analyzer over the packages and then
+// prints the findings in the requested format.
+//
+// Like the singlechecker -json mode, the report modes don't treat
+// findings as a failure, the exit code is non-zero only for the errors.
+// Without -format, the findings are printed like the singlechecker does.
+//
+// If -stats is set, the stats table is printed to the stderr at the end.
+func runDriver(flags driverFlags, args []string) int {
+	switch flags.format {
+	case "", "sarif", "json":
+		// OK.
+	default:
+		fmt.Fprintf(os.Stderr, "ruleguard: unexpected -format value %q, expected sarif or json\n", flags.format)
+		return 1
+	}
+
+	if flags.stats {
+		// Print a single table for all packages instead of
+		// the analyzer per-package tables.
+		analyzer.StatsWriter = nil
+	}
+
+	diagnostics, exitCode := collectDiagnostics(args)
+	if exitCode != 0 {
+		return exitCode
+	}
+
+	if flags.stats {
+		defer func() {
+			if err := analyzer.Stats().WriteTable(os.Stderr); err != nil {
+				fmt.Fprintf(os.Stderr, "ruleguard: write stats: %v\n", err)
+			}
+		}()
+	}
+
+	var err error
+	groups := analyzer.LoadedGroups()
+	switch flags.format {
+	case "":
+		for _, d := range diagnostics {
+			fmt.Fprintf(os.Stderr, "%s: %s\n", d.From, d.Message)
+		}
+		if len(diagnostics) != 0 {
+			return 3
+		}
+	case "sarif":
+		err = writeSARIF(os.Stdout, groups, diagnostics)
+	case "json":
+		err = writeJSON(os.Stdout, groups, diagnostics)
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "ruleguard: write report: %v\n", err)
+		return 1
+	}
+	return 0
+}
+
+// collectDiagnostics runs the analyzer over the packages specified by args.
+// Returned diagnostics are sorted by their position.
+func collectDiagnostics(args []string) ([]ruleguard.Diagnostic, int) {
+	fs := flag.NewFlagSet("ruleguard", flag.ExitOnError)
+	analyzer.Analyzer.Flags.VisitAll(func(f *flag.Flag) {
+		fs.Var(f.Value, f.Name, f.Usage)
+	})
+	withTests := fs.Bool("test", true, "indicates whether test files should be analyzed, too")
+	_ = fs.Parse(args)
+
+	cfg := &packages.Config{
+		Mode:  packages.LoadAllSyntax,
+		Tests: *withTests,
+	}
+	pkgs, err := packages.Load(cfg, fs.Args()...)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "ruleguard: %v\n", err)
+		return nil, 1
+	}
+	if packages.PrintErrors(pkgs) != 0 {
+		return nil, 1
+	}
+
+	graph, err := checker.Analyze([]*analysis.Analyzer{analyzer.Analyzer}, pkgs, nil)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "ruleguard: %v\n", err)
+		return nil, 1
+	}
+
+	groups := make(map[string]*ruleguard.GoRuleGroup)
+	loaded := analyzer.LoadedGroups()
+	for i := range loaded {
+		groups[loaded[i].Name] = &loaded[i]
+	}
+
+	exitCode := 0
+	var diagnostics []ruleguard.Diagnostic
+	type diagnosticKey struct {
+		pos     token.Position
+		message string
+	}
+	seen := make(map[diagnosticKey]bool)
+	for _, act := range graph.Roots {
+		if act.Err != nil {
+			fmt.Fprintf(os.Stderr, "ruleguard: %s: %v\n", act.Package.PkgPath, act.Err)
+			exitCode = 1
+			continue
+		}
+		fset := act.Package.Fset
+		for _, diag := range act.Diagnostics {
+			d := newDiagnostic(fset, groups[diag.Category], diag)
+			// With -test=true the same file can be a part of
+			// several packages, so we need to remove the duplicates.
+			key := diagnosticKey{pos: d.From, message: d.Message}
+			if seen[key] {
+				continue
+			}
+			seen[key] = true
+			diagnostics = append(diagnostics, d)
+		}
+	}
+	ruleguard.SortDiagnostics(diagnostics)
+
+	return diagnostics, exitCode
+}
+
+// newDiagnostic converts the analyzer diagnostic back to the ruleguard.Diagnostic.
+//
+// The group is nil for the reports that are not produced by the rule groups.
+// The analyzer diagnostics don't carry the matched rule line,
+// so the RuleLine and Fingerprint fields are always empty.
+func newDiagnostic(fset *token.FileSet, group *ruleguard.GoRuleGroup, diag analysis.Diagnostic) ruleguard.Diagnostic {
+	d := ruleguard.Diagnostic{
+		Group:    diag.Category,
+		Message:  diag.Message,
+		Severity: ruleguard.DefaultSeverity,
+		From:     fset.Position(diag.Pos),
+		To:       fset.Position(diag.Pos),
+	}
+	if group != nil {
+		d.RulesFile = group.Filename
+		d.Severity = group.Severity
+	}
+	if diag.End.IsValid() {
+		d.To = fset.Position(diag.End)
+	}
+	for _, fix := range diag.SuggestedFixes {
+		resolved := ruleguard.DiagnosticSuggestion{Label: fix.Message}
+		for _, edit := range fix.TextEdits {
+			e := ruleguard.DiagnosticEdit{
+				From:        fset.Position(edit.Pos),
+				To:          fset.Position(edit.Pos),
+				Replacement: string(edit.NewText),
+			}
+			if edit.End.IsValid() {
+				e.To = fset.Position(edit.End)
+			}
+			resolved.Edits = append(resolved.Edits, e)
+		}
+		d.Suggestions = append(d.Suggestions, resolved)
+	}
+	return d
+}
+
+// relativePath returns a slash-separated filename relative to the working directory.
+// If that's not possible, an absolute path is returned.
+func relativePath(filename string) string {
+	wd, err := os.Getwd()
+	if err != nil {
+		return filepath.ToSlash(filename)
+	}
+	rel, err := filepath.Rel(wd, filename)
+	if err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		return filepath.ToSlash(filename)
+	}
+	return filepath.ToSlash(rel)
+}
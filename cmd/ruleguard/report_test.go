@@ -0,0 +1,185 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"go/token"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/quasilyte/go-ruleguard/analyzer"
+	"github.com/quasilyte/go-ruleguard/ruleguard"
+)
+
+func TestExtractDriverFlags(t *testing.T) {
+	tests := []struct {
+		args  []string
+		flags driverFlags
+		rest  []string
+	}{
+		{[]string{"./..."}, driverFlags{}, []string{"./..."}},
+		{[]string{"-format", "sarif", "./..."}, driverFlags{format: "sarif"}, []string{"./..."}},
+		{[]string{"-rules", "rules.go", "--format=json", "./..."}, driverFlags{format: "json"}, []string{"-rules", "rules.go", "./..."}},
+		{[]string{"-rules", "rules.go", "--", "-format=json"}, driverFlags{}, []string{"-rules", "rules.go", "--", "-format=json"}},
+		{[]string{"-fix", "-rules", "rules.go", "."}, driverFlags{fix: true}, []string{"-fix", "-rules", "rules.go", "."}},
+		{[]string{"-fix", "-diff", "."}, driverFlags{fix: true}, []string{"-fix", "-diff", "."}},
+		{[]string{"-rules", "rules.go", "-diff", "."}, driverFlags{fix: true}, []string{"-fix", "-rules", "rules.go", "-diff", "."}},
+		{[]string{"-fix=false", "-diff=true", "."}, driverFlags{fix: true}, []string{"-fix", "-diff=true", "."}},
+		{[]string{"-fix=false", "-format=json", "."}, driverFlags{format: "json"}, []string{"."}},
+		{[]string{"-stats", "-rules", "rules.go", "."}, driverFlags{stats: true}, []string{"-stats", "-rules", "rules.go", "."}},
+	}
+
+	for _, test := range tests {
+		flags, rest := extractDriverFlags(test.args)
+		if diff := cmp.Diff(test.flags, flags, cmp.AllowUnexported(driverFlags{})); diff != "" {
+			t.Errorf("extractDriverFlags(%q) flags (-want +have):\n%s", test.args, diff)
+		}
+		if diff := cmp.Diff(test.rest, rest); diff != "" {
+			t.Errorf("extractDriverFlags(%q) rest (-want +have):\n%s", test.args, diff)
+		}
+	}
+}
+
+func TestWriteSARIF(t *testing.T) {
+	groups := []ruleguard.GoRuleGroup{
+		{
+			Name:       "bytesCompare",
+			Filename:   "rules.go",
+			Line:       10,
+			DocSummary: "Detects bytes.Compare equality checks",
+			DocBefore:  "bytes.Compare(x, y) == 0",
+			DocAfter:   "bytes.Equal(x, y)",
+			DocTags:    []string{"style"},
+			Severity:   ruleguard.SeverityInfo,
+		},
+	}
+	pos := func(line, column int) token.Position {
+		return token.Position{Filename: "target.go", Line: line, Column: column}
+	}
+	diagnostics := []ruleguard.Diagnostic{
+		{
+			Group:    "bytesCompare",
+			Message:  "suggestion: bytes.Equal(a, b)",
+			Severity: ruleguard.SeverityInfo,
+			From:     pos(6, 9),
+			To:       pos(6, 33),
+			Suggestions: []ruleguard.DiagnosticSuggestion{
+				{
+					Label: "use bytes.Equal",
+					Edits: []ruleguard.DiagnosticEdit{{From: pos(6, 9), To: pos(6, 33), Replacement: "bytes.Equal(a, b)"}},
+				},
+			},
+		},
+	}
+
+	var buf bytes.Buffer
+	if err := writeSARIF(&buf, groups, diagnostics); err != nil {
+		t.Fatal(err)
+	}
+	var log sarifLog
+	if err := json.Unmarshal(buf.Bytes(), &log); err != nil {
+		t.Fatal(err)
+	}
+
+	want := sarifLog{
+		Version: "2.1.0",
+		Schema:  "https://json.schemastore.org/sarif-2.1.0.json",
+		Runs: []sarifRun{{
+			Tool: sarifTool{Driver: sarifDriver{
+				Name:           "ruleguard",
+				InformationURI: "https://github.com/quasilyte/go-ruleguard",
+				Rules: []sarifReportingDescriptor{{
+					ID:               "bytesCompare",
+					ShortDescription: &sarifMessage{Text: "Detects bytes.Compare equality checks"},
+					Help: &sarifMessage{
+						Text:     "Before:\nbytes.Compare(x, y) == 0\nAfter:\nbytes.Equal(x, y)\n",
+						Markdown: "Before:\n```go\nbytes.Compare(x, y) == 0\n```\nAfter:\n```go\nbytes.Equal(x, y)\n```\n",
+					},
+					DefaultConfiguration: sarifRuleConfiguration{Level: "note"},
+					Properties:           sarifRuleProperties{Tags: []string{"style"}, RulesFile: "rules.go:10"},
+				}},
+			}},
+			ColumnKind: "utf16CodeUnits",
+			Results: []sarifResult{{
+				RuleID:  "bytesCompare",
+				Level:   "note",
+				Message: sarifMessage{Text: "suggestion: bytes.Equal(a, b)"},
+				Locations: []sarifLocation{{PhysicalLocation: sarifPhysicalLocation{
+					ArtifactLocation: sarifArtifactLocation{URI: "target.go"},
+					Region:           sarifRegion{StartLine: 6, StartColumn: 9, EndLine: 6, EndColumn: 33},
+				}}},
+				Fixes: []sarifFix{{
+					Description: sarifMessage{Text: "use bytes.Equal"},
+					ArtifactChanges: []sarifArtifactChange{{
+						ArtifactLocation: sarifArtifactLocation{URI: "target.go"},
+						Replacements: []sarifReplacement{{
+							DeletedRegion:   sarifRegion{StartLine: 6, StartColumn: 9, EndLine: 6, EndColumn: 33},
+							InsertedContent: sarifMessage{Text: "bytes.Equal(a, b)"},
+						}},
+					}},
+				}},
+			}},
+		}},
+	}
+	if diff := cmp.Diff(want, log); diff != "" {
+		t.Errorf("SARIF output mismatch (-want +have):\n%s", diff)
+	}
+
+	// Reports that don't come from the rule groups get a synthetic rule.
+	diagnostics[0].Group = analyzer.UnusedSuppressionCategory
+	diagnostics[0].Severity = ruleguard.DefaultSeverity
+	buf.Reset()
+	if err := writeSARIF(&buf, groups, diagnostics); err != nil {
+		t.Fatal(err)
+	}
+	log = sarifLog{}
+	if err := json.Unmarshal(buf.Bytes(), &log); err != nil {
+		t.Fatal(err)
+	}
+	run := log.Runs[0]
+	wantRule := sarifReportingDescriptor{
+		ID:                   analyzer.UnusedSuppressionCategory,
+		ShortDescription:     &sarifMessage{Text: "Reports //ruleguard:ignore directives that don't suppress anything"},
+		DefaultConfiguration: sarifRuleConfiguration{Level: "warning"},
+	}
+	if diff := cmp.Diff(wantRule, run.Tool.Driver.Rules[1]); diff != "" {
+		t.Errorf("synthetic rule mismatch (-want +have):\n%s", diff)
+	}
+	if run.Results[0].RuleIndex != 1 || run.Results[0].Level != "warning" {
+		t.Errorf("unexpected synthetic rule result: index=%d level=%s", run.Results[0].RuleIndex, run.Results[0].Level)
+	}
+}
+
+func TestSARIFColumns(t *testing.T) {
+	filename := filepath.Join(t.TempDir(), "target.go")
+	src := "package target\n\nvar s = \"привет 🙂\" + x\n"
+	if err := os.WriteFile(filename, []byte(src), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	line3 := strings.Index(src, "var")
+	pos := func(s string) token.Position {
+		offset := strings.Index(src, s)
+		return token.Position{Filename: filename, Offset: offset, Line: 3, Column: offset - line3 + 1}
+	}
+
+	columns := sarifColumns{files: make(map[string][]byte)}
+	tests := []struct {
+		pos  token.Position
+		want int
+	}{
+		{pos("var"), 1},
+		{pos(`"`), 9},
+		// 6 two-byte cyrillic letters and a 4-byte emoji (a surrogate pair).
+		{pos("+ x"), 21},
+		{pos("x\n"), 23},
+	}
+	for _, test := range tests {
+		if have := columns.column(test.pos); have != test.want {
+			t.Errorf("column(%v): have %d, want %d", test.pos, have, test.want)
+		}
+	}
+}
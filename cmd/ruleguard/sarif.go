@@ -0,0 +1,288 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"go/token"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/quasilyte/go-ruleguard/analyzer"
+	"github.com/quasilyte/go-ruleguard/ruleguard"
+)
+
+// This file implements a minimal subset of SARIF 2.1.0 that
+// is enough to describe the ruleguard findings and their fixes.
+//
+// See https://docs.oasis-open.org/sarif/sarif/v2.1.0/sarif-v2.1.0.html
+
+type sarifLog struct {
+	Version string     `json:"version"`
+	Schema  string     `json:"$schema"`
+	Runs    []sarifRun `json:"runs"`
+}
+
+type sarifRun struct {
+	Tool       sarifTool     `json:"tool"`
+	ColumnKind string        `json:"columnKind"`
+	Results    []sarifResult `json:"results"`
+}
+
+type sarifTool struct {
+	Driver sarifDriver `json:"driver"`
+}
+
+type sarifDriver struct {
+	Name           string                     `json:"name"`
+	Version        string                     `json:"version,omitempty"`
+	InformationURI string                     `json:"informationUri"`
+	Rules          []sarifReportingDescriptor `json:"rules"`
+}
+
+type sarifReportingDescriptor struct {
+	ID                   string                 `json:"id"`
+	ShortDescription     *sarifMessage          `json:"shortDescription,omitempty"`
+	FullDescription      *sarifMessage          `json:"fullDescription,omitempty"`
+	Help                 *sarifMessage          `json:"help,omitempty"`
+	DefaultConfiguration sarifRuleConfiguration `json:"defaultConfiguration"`
+	Properties           sarifRuleProperties    `json:"properties"`
+}
+
+type sarifRuleConfiguration struct {
+	Level string `json:"level"`
+}
+
+type sarifRuleProperties struct {
+	Tags []string `json:"tags,omitempty"`
+
+	// RulesFile is a rules file location of the group, like "rules.go:10".
+	// It's empty for the reports that are not produced by the rule groups.
+	RulesFile string `json:"rulesFile,omitempty"`
+}
+
+type sarifMessage struct {
+	Text     string `json:"text"`
+	Markdown string `json:"markdown,omitempty"`
+}
+
+type sarifResult struct {
+	RuleID    string          `json:"ruleId"`
+	RuleIndex int             `json:"ruleIndex"`
+	Level     string          `json:"level"`
+	Message   sarifMessage    `json:"message"`
+	Locations []sarifLocation `json:"locations"`
+	Fixes     []sarifFix      `json:"fixes,omitempty"`
+}
+
+type sarifLocation struct {
+	PhysicalLocation sarifPhysicalLocation `json:"physicalLocation"`
+}
+
+type sarifPhysicalLocation struct {
+	ArtifactLocation sarifArtifactLocation `json:"artifactLocation"`
+	Region           sarifRegion           `json:"region"`
+}
+
+type sarifArtifactLocation struct {
+	URI string `json:"uri"`
+}
+
+type sarifRegion struct {
+	StartLine   int `json:"startLine"`
+	StartColumn int `json:"startColumn"`
+	EndLine     int `json:"endLine"`
+	EndColumn   int `json:"endColumn"`
+}
+
+type sarifFix struct {
+	Description     sarifMessage          `json:"description"`
+	ArtifactChanges []sarifArtifactChange `json:"artifactChanges"`
+}
+
+type sarifArtifactChange struct {
+	ArtifactLocation sarifArtifactLocation `json:"artifactLocation"`
+	Replacements     []sarifReplacement    `json:"replacements"`
+}
+
+type sarifReplacement struct {
+	DeletedRegion   sarifRegion  `json:"deletedRegion"`
+	InsertedContent sarifMessage `json:"insertedContent"`
+}
+
+func writeSARIF(w io.Writer, groups []ruleguard.GoRuleGroup, diagnostics []ruleguard.Diagnostic) error {
+	driver := sarifDriver{
+		Name:           "ruleguard",
+		Version:        analyzer.Version,
+		InformationURI: "https://github.com/quasilyte/go-ruleguard",
+		Rules:          make([]sarifReportingDescriptor, 0, len(groups)),
+	}
+	ruleIndex := make(map[string]int, len(groups))
+	for _, g := range groups {
+		ruleIndex[g.Name] = len(driver.Rules)
+		driver.Rules = append(driver.Rules, newSARIFRule(g))
+	}
+
+	columns := sarifColumns{files: make(map[string][]byte)}
+	results := make([]sarifResult, 0, len(diagnostics))
+	for _, d := range diagnostics {
+		index, ok := ruleIndex[d.Group]
+		if !ok {
+			// Not a rule group report, like the unused suppression directive.
+			index = len(driver.Rules)
+			ruleIndex[d.Group] = index
+			driver.Rules = append(driver.Rules, newSyntheticSARIFRule(d.Group))
+		}
+		result := sarifResult{
+			RuleID:    d.Group,
+			RuleIndex: index,
+			Level:     sarifLevel(d.Severity),
+			Message:   sarifMessage{Text: d.Message},
+			Locations: []sarifLocation{
+				{
+					PhysicalLocation: sarifPhysicalLocation{
+						ArtifactLocation: sarifArtifactLocation{URI: relativePath(d.From.Filename)},
+						Region:           columns.region(d.From, d.To),
+					},
+				},
+			},
+		}
+		for _, s := range d.Suggestions {
+			result.Fixes = append(result.Fixes, newSARIFFix(&columns, s))
+		}
+		results = append(results, result)
+	}
+
+	log := sarifLog{
+		Version: "2.1.0",
+		Schema:  "https://json.schemastore.org/sarif-2.1.0.json",
+		Runs: []sarifRun{
+			{Tool: sarifTool{Driver: driver}, ColumnKind: "utf16CodeUnits", Results: results},
+		},
+	}
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(log)
+}
+
+func newSARIFRule(g ruleguard.GoRuleGroup) sarifReportingDescriptor {
+	rule := sarifReportingDescriptor{
+		ID:                   g.Name,
+		DefaultConfiguration: sarifRuleConfiguration{Level: sarifLevel(g.Severity)},
+		Properties: sarifRuleProperties{
+			Tags:      g.DocTags,
+			RulesFile: fmt.Sprintf("%s:%d", g.Filename, g.Line),
+		},
+	}
+	if g.DocSummary != "" {
+		rule.ShortDescription = &sarifMessage{Text: g.DocSummary}
+	}
+	if g.DocNote != "" {
+		rule.FullDescription = &sarifMessage{Text: g.DocNote}
+	}
+
+	if g.DocBefore != "" || g.DocAfter != "" {
+		var text strings.Builder
+		var markdown strings.Builder
+		if g.DocBefore != "" {
+			fmt.Fprintf(&text, "Before:\n%s\n", g.DocBefore)
+			fmt.Fprintf(&markdown, "Before:\n```go\n%s\n```\n", g.DocBefore)
+		}
+		if g.DocAfter != "" {
+			fmt.Fprintf(&text, "After:\n%s\n", g.DocAfter)
+			fmt.Fprintf(&markdown, "After:\n```go\n%s\n```\n", g.DocAfter)
+		}
+		rule.Help = &sarifMessage{Text: text.String(), Markdown: markdown.String()}
+	}
+
+	return rule
+}
+
+// sarifExtraRules describes the reports that are not produced by the rule groups.
+var sarifExtraRules = map[string]string{
+	analyzer.UnusedSuppressionCategory: "Reports //ruleguard:ignore directives that don't suppress anything",
+}
+
+func newSyntheticSARIFRule(id string) sarifReportingDescriptor {
+	rule := sarifReportingDescriptor{
+		ID:                   id,
+		DefaultConfiguration: sarifRuleConfiguration{Level: sarifLevel(ruleguard.DefaultSeverity)},
+	}
+	if doc, ok := sarifExtraRules[id]; ok {
+		rule.ShortDescription = &sarifMessage{Text: doc}
+	}
+	return rule
+}
+
+func newSARIFFix(columns *sarifColumns, s ruleguard.DiagnosticSuggestion) sarifFix {
+	result := sarifFix{Description: sarifMessage{Text: s.Label}}
+	// Edits are grouped by the file; most of the time,
+	// there is only one file per fix.
+	changeIndex := make(map[string]int)
+	for _, e := range s.Edits {
+		uri := relativePath(e.From.Filename)
+		i, ok := changeIndex[uri]
+		if !ok {
+			i = len(result.ArtifactChanges)
+			changeIndex[uri] = i
+			result.ArtifactChanges = append(result.ArtifactChanges, sarifArtifactChange{
+				ArtifactLocation: sarifArtifactLocation{URI: uri},
+			})
+		}
+		change := &result.ArtifactChanges[i]
+		change.Replacements = append(change.Replacements, sarifReplacement{
+			DeletedRegion:   columns.region(e.From, e.To),
+			InsertedContent: sarifMessage{Text: e.Replacement},
+		})
+	}
+	return result
+}
+
+// sarifColumns converts the byte-based token.Position columns
+// into the UTF-16 code units, so the non-ASCII lines get correct columns.
+type sarifColumns struct {
+	files map[string][]byte
+}
+
+func (c *sarifColumns) region(from, to token.Position) sarifRegion {
+	return sarifRegion{
+		StartLine:   from.Line,
+		StartColumn: c.column(from),
+		EndLine:     to.Line,
+		EndColumn:   c.column(to),
+	}
+}
+
+func (c *sarifColumns) column(pos token.Position) int {
+	src, ok := c.files[pos.Filename]
+	if !ok {
+		// If the file can't be read, src is nil
+		// and we fall back to the byte columns.
+		src, _ = os.ReadFile(pos.Filename)
+		c.files[pos.Filename] = src
+	}
+	lineStart := pos.Offset - (pos.Column - 1)
+	if lineStart < 0 || pos.Offset > len(src) {
+		return pos.Column
+	}
+	column := 1
+	for _, r := range string(src[lineStart:pos.Offset]) {
+		if r >= 0x10000 {
+			column += 2 // A surrogate pair
+		} else {
+			column++
+		}
+	}
+	return column
+}
+
+func sarifLevel(severity ruleguard.Severity) string {
+	switch severity {
+	case ruleguard.SeverityError:
+		return "error"
+	case ruleguard.SeverityInfo:
+		return "note"
+	default:
+		return "warning"
+	}
+}
@@ -0,0 +1,85 @@
+// Package ast mimics the https://golang.org/pkg/go/ast/ package.
+// It also contains some extra utility functions, they're defined in ext.go file.
+//
+// All nodes are read-only.
+package ast
+
+// An Expr represents an expression node.
+type Expr interface {
+	exprNode()
+}
+
+type (
+	// An Ident represents an identifier.
+	Ident struct{}
+
+	// A BasicLit represents a literal of basic type.
+	BasicLit struct{}
+
+	// A CallExpr represents an expression followed by an argument list.
+	CallExpr struct{}
+
+	// A SelectorExpr represents an expression followed by a selector.
+	SelectorExpr struct{}
+
+	// A CompositeLit represents a composite literal.
+	CompositeLit struct{}
+
+	// A FuncLit represents a function literal.
+	FuncLit struct{}
+)
+
+func (*Ident) exprNode()        {}
+func (*BasicLit) exprNode()     {}
+func (*CallExpr) exprNode()     {}
+func (*SelectorExpr) exprNode() {}
+func (*CompositeLit) exprNode() {}
+func (*FuncLit) exprNode()      {}
+
+// Name returns the identifier name.
+func (*Ident) Name() string { return "" }
+
+// Kind returns the literal kind: "INT", "FLOAT", "IMAG", "CHAR" or "STRING".
+func (*BasicLit) Kind() string { return "" }
+
+// Value returns the literal value as it's written in the source code,
+// e.g. 42, 0x7f, 3.14, 1e-9, 2.4i, 'a', '\x7f', "foo" or `\m\n\o`.
+func (*BasicLit) Value() string { return "" }
+
+// Fun returns the function expression.
+func (*CallExpr) Fun() Expr { return nil }
+
+// NumArgs returns the number of call arguments.
+func (*CallExpr) NumArgs() int { return 0 }
+
+// Arg returns the i'th call argument for 0 <= i < NumArgs().
+func (*CallExpr) Arg(i int) Expr { return nil }
+
+// HasEllipsis reports whether the last argument is followed by "...".
+func (*CallExpr) HasEllipsis() bool { return false }
+
+// X returns the selector operand expression.
+func (*SelectorExpr) X() Expr { return nil }
+
+// Sel returns the field selector.
+func (*SelectorExpr) Sel() *Ident { return nil }
+
+// Type returns the literal type expression.
+// Returns nil if the type is elided, like in nested literals.
+func (*CompositeLit) Type() Expr { return nil }
+
+// NumElts returns the number of literal elements.
+func (*CompositeLit) NumElts() int { return 0 }
+
+// Elt returns the i'th literal element for 0 <= i < NumElts().
+func (*CompositeLit) Elt(i int) Expr { return nil }
+
+// NumParams returns the number of function parameters.
+// For func(a, b int, c string) it returns 3.
+func (*FuncLit) NumParams() int { return 0 }
+
+// NumResults returns the number of function results.
+func (*FuncLit) NumResults() int { return 0 }
+
+// NumStmts returns the number of top-level statements inside the function body.
+func (*FuncLit) NumStmts() int { return 0 }
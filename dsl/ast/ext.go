@@ -0,0 +1,28 @@
+package ast
+
+// AsIdent is a type-assert like operation, x.(*Ident), but never panics.
+// Returns nil if expression is not an identifier.
+func AsIdent(x Expr) *Ident { return nil }
+
+// AsBasicLit is a type-assert like operation, x.(*BasicLit), but never panics.
+// Returns nil if expression is not a basic literal.
+func AsBasicLit(x Expr) *BasicLit { return nil }
+
+// AsCallExpr is a type-assert like operation, x.(*CallExpr), but never panics.
+// Returns nil if expression is not a call.
+func AsCallExpr(x Expr) *CallExpr { return nil }
+
+// AsSelectorExpr is a type-assert like operation, x.(*SelectorExpr), but never panics.
+// Returns nil if expression is not a selector expression.
+func AsSelectorExpr(x Expr) *SelectorExpr { return nil }
+
+// AsCompositeLit is a type-assert like operation, x.(*CompositeLit), but never panics.
+// Returns nil if expression is not a composite literal.
+func AsCompositeLit(x Expr) *CompositeLit { return nil }
+
+// AsFuncLit is a type-assert like operation, x.(*FuncLit), but never panics.
+// Returns nil if expression is not a function literal.
+func AsFuncLit(x Expr) *FuncLit { return nil }
+
+// Unparen returns the expression with any enclosing parentheses removed.
+func Unparen(x Expr) Expr { return nil }
@@ -0,0 +1,48 @@
+// Package constant mimics the https://golang.org/pkg/go/constant/ package.
+//
+// Unlike the go/constant package, the value accessors never panic:
+// they return a zero value if x can't be represented by the requested type.
+package constant
+
+// Kind specifies the kind of value represented by a Value.
+type Kind int
+
+const (
+	// Unknown values are used for expressions that are not constant.
+	Unknown Kind = iota
+
+	Bool
+	String
+	Int
+	Float
+	Complex
+)
+
+// A Value represents the value of a Go constant.
+type Value interface {
+	// Kind returns the value kind.
+	Kind() Kind
+
+	// String returns a short, quoted (human-readable) form of the value.
+	String() string
+
+	// ExactString returns an exact, quoted (human-readable) form of the value.
+	ExactString() string
+}
+
+// BoolVal returns the Go boolean value of x.
+// Returns false if x is not a Bool.
+func BoolVal(x Value) bool { return false }
+
+// StringVal returns the Go string value of x.
+// Returns "" if x is not a String.
+func StringVal(x Value) string { return "" }
+
+// IntVal returns the Go int value of x and whether the result is exact.
+// Float values with an integer value are accepted too.
+// Returns (0, false) if x can't be represented as an int.
+func IntVal(x Value) (int, bool) { return 0, false }
+
+// Float64Val returns the nearest Go float64 value of x and whether the result is exact.
+// Returns (0, false) if x is not a numeric value.
+func Float64Val(x Value) (float64, bool) { return 0, false }
@@ -1,6 +1,8 @@
 package dsl
 
 import (
+	"github.com/quasilyte/go-ruleguard/dsl/ast"
+	"github.com/quasilyte/go-ruleguard/dsl/constant"
 	"github.com/quasilyte/go-ruleguard/dsl/types"
 )
 
@@ -17,3 +19,7 @@ type DoVar struct{}
 func (*DoVar) Text() string { return "" }
 
 func (*DoVar) Type() types.Type { return nil }
+
+func (*DoVar) Node() ast.Expr { return nil }
+
+func (*DoVar) Value() constant.Value { return nil }
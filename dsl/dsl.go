@@ -63,6 +63,29 @@ func (m Matcher) Where(cond bool) Matcher {
 	return m
 }
 
+// PackageWhere makes the rule package-scoped.
+//
+// Instead of being reported right away, the matches that passed the Where()
+// filters are collected from all package files. Matches are grouped by
+// the text of the groupBy vars; without groupBy vars, all matches of the
+// rule belong to a single group. After the entire package is processed,
+// cond is evaluated once per group and if it's true, every match
+// of the group is reported.
+//
+// Only m.Matches() conditions can be used inside cond.
+//
+// Package-scoped rules are executed by the analyzer and the Engine.RunPackage();
+// the Engine.Run() works with a single file and skips them.
+//
+// Example, init functions that are defined in several files:
+//
+//     m.Match(`func init() { $*_ }`).
+//         PackageWhere(m.Matches().Files > 1).
+//         Report(`package has init() functions in several files`)
+func (m Matcher) PackageWhere(cond bool, groupBy ...Var) Matcher {
+	return m
+}
+
 // Report prints a message if associated rule match is successful.
 //
 // A message is a string that can contain interpolated expressions.
@@ -74,10 +97,36 @@ func (m Matcher) Report(message string) Matcher {
 }
 
 // Suggest assigns a quickfix suggestion for the matched code.
+//
+// Suggest can be called several times for the same rule.
+// Every call adds a separate fix, so the user can pick the one
+// that fits the context best.
 func (m Matcher) Suggest(suggestion string) Matcher {
 	return m
 }
 
+// SuggestLabeled is like Suggest, but it also gives the fix a name.
+// The label is displayed by tools that let the user choose between
+// several fixes, like "use strings.EqualFold".
+func (m Matcher) SuggestLabeled(label, suggestion string) Matcher {
+	return m
+}
+
+// SuggestAt adds one more edit to the last Suggest (or SuggestLabeled) fix.
+// Unlike Suggest, it replaces the v node instead of the reported one.
+// If there are no Suggest calls before it, a new fix is started.
+//
+// All edits of the same fix are applied together. This makes it possible
+// to rewrite several parts of the code in one fix, for example,
+// a declaration and its use.
+//
+// Packages that are referenced by the suggestion templates, like `strings`
+// in `strings.EqualFold($x, $y)`, are imported automatically
+// if the file doesn't import them yet.
+func (m Matcher) SuggestAt(v Var, suggestion string) Matcher {
+	return m
+}
+
 func (m Matcher) Do(fn func(*DoContext)) Matcher {
 	return m
 }
@@ -94,7 +143,22 @@ func (m Matcher) File() File { return File{} }
 // GoVersion returns the analyzer associated target Go language version.
 func (m Matcher) GoVersion() GoVersion { return GoVersion{} }
 
+// Func returns the enclosing function declaration context.
+//
+// Function literals are not taken into account: the closest FuncDecl is used.
+// For a matched function declaration, it's the matched function itself.
+// If a match is located outside of any function, all Func() conditions are false.
+func (m Matcher) Func() Func { return Func{} }
+
+// Matches returns the package-scoped rule matches group info.
+// It can only be used inside the PackageWhere() condition.
+func (m Matcher) Matches() PackageMatches { return PackageMatches{} }
+
 // Deadcode reports whether this match is contained inside a dead code path.
+//
+// The code is dead if it's located inside a branch with a constant false
+// condition or if it's unreachable according to the function control flow graph,
+// like the statements after a return or a panic() call.
 func (m Matcher) Deadcode() bool { return boolResult }
 
 // Var is a pattern variable that describes a named submatch.
@@ -174,6 +238,48 @@ func (Var) Filter(pred func(*VarFilterContext) bool) bool { return boolResult }
 // Experimental: this function is not part of the stable API.
 func (Var) Contains(pattern string) bool { return boolResult }
 
+// NotContains is a negated form of Contains.
+// It reports whether there are no pattern matches inside the submatch.
+//
+// Experimental: this function is not part of the stable API.
+func (Var) NotContains(pattern string) bool { return boolResult }
+
+// FollowedBy reports whether a statement that contains the submatch
+// is followed by a statement matching the pattern.
+//
+// Only the statements from the same block (or case clause) are checked.
+// The nested statements are not inspected: with the `defer $f.Close()`
+// pattern, a defer statement inside the following if statement is not matched.
+//
+// Like with Contains, the captured vars from the original pattern match are
+// bound while matching the pattern.
+//
+// Experimental: this function is not part of the stable API.
+func (Var) FollowedBy(pattern string) bool { return boolResult }
+
+// PrecededBy is like FollowedBy, but it checks the statements
+// that precede the statement that contains the submatch.
+//
+// Experimental: this function is not part of the stable API.
+func (Var) PrecededBy(pattern string) bool { return boolResult }
+
+// AlwaysFollowedBy reports whether every control flow path from the statement
+// that contains the submatch to the function exit goes through a statement
+// matching the pattern.
+//
+// Unlike FollowedBy, the statements outside of the current block are checked too:
+// `AlwaysFollowedBy("$mu.Unlock()")` is true if there is no return from
+// the function that skips the unlock.
+// The paths that end with a panic() or os.Exit() call are not considered.
+// For the control statements like `if`, the paths start after the statement:
+// a return from the if body is not checked.
+//
+// Function literal bodies are not inspected, a deferred call should be
+// matched as a whole: `AlwaysFollowedBy("defer $mu.Unlock()")`.
+//
+// Experimental: this function is not part of the stable API.
+func (Var) AlwaysFollowedBy(pattern string) bool { return boolResult }
+
 // MatchedNode represents an AST node associated with a named submatch.
 type MatchedNode struct{}
 
@@ -186,6 +292,28 @@ func (MatchedNode) Is(typ string) bool { return boolResult }
 // Parent returns a matched node parent.
 func (MatchedNode) Parent() Node { return Node{} }
 
+// Ancestor reports whether any of the matched node ancestors has the specified type.
+// The type argument is interpreted like in Is() method.
+//
+// The search is not limited by the function boundaries:
+// a node inside a `go func() { ... }()` body has both FuncLit and GoStmt ancestors.
+//
+// Some useful examples:
+//
+//	Ancestor("DeferStmt")  -- inside a deferred call
+//	Ancestor("GoStmt")     -- inside a go statement (including its function literal body)
+//	Ancestor("CommClause") -- inside a select case
+func (MatchedNode) Ancestor(typ string) bool { return boolResult }
+
+// InsideLoop reports whether the matched node can be executed several times
+// because of the enclosing for or range loop.
+//
+// Unlike Ancestor(), it stops at the closest function boundary,
+// so a node inside a function literal body is not considered to be inside
+// a loop that contains that function literal.
+// A for loop init statement and a range loop expression are also not a part of the loop.
+func (MatchedNode) InsideLoop() bool { return boolResult }
+
 // Node represents an AST node somewhere inside a match.
 // Unlike MatchedNode, it doesn't have to be associated with a named submatch.
 type Node struct{}
@@ -217,6 +345,37 @@ func (TypesObject) IsGlobal() bool { return boolResult }
 // This property is not propagated between the assignments.
 func (TypesObject) IsVariadicParam() bool { return boolResult }
 
+// DefinedBy reports whether a variable is initialized by an expression
+// that matches the given gogrep pattern.
+//
+// Only local variables are supported. The initializer is found
+// inside the enclosing function declaration: it's either a `:=` assignment
+// or a `var` declaration. For the tuple assignments like `x, err := f()`,
+// the pattern is matched against the `f()` call.
+//
+// The filter fails if a variable can have more than one value:
+// it's assigned or incremented after the definition, or its address is taken.
+//
+// Like with Contains, the captured vars from the original pattern are bound.
+func (TypesObject) DefinedBy(pattern string) bool { return boolResult }
+
+// Calls reports whether an associated function calls the specified function,
+// directly or through other functions of the same package.
+// The function name uses the types.Func.FullName() format:
+// "log.Fatal", "(*net/http.Client).Do".
+//
+// Only the files given to the engine are inspected; when rules
+// are executed file by file, other files of the package are not visible.
+//
+// The call graph is an under-approximation: only the static calls are followed.
+// Interface method calls, function value calls and function literals
+// that are not called immediately (like callbacks passed to other functions)
+// are ignored. Only immediately called literals, like `go func() { ... }()`,
+// are treated as a part of the enclosing function.
+// So a true result is reliable, but a false result doesn't prove that
+// the function can't call fn; be careful when negating this filter.
+func (TypesObject) Calls(fn string) bool { return boolResult }
+
 type SinkType struct{}
 
 // Is reports whether a type is identical to a given type.
@@ -268,6 +427,11 @@ func (ExprType) Implements(typ typeName) bool { return boolResult }
 func (ExprType) HasMethod(fn string) bool { return boolResult }
 
 // Is reports whether a type is identical to a given type.
+//
+// Besides the ordinary Go types, typ can be a type pattern, like `[]$T`.
+// Generic types are matched with the index expressions, like `atomic.Pointer[$T]`.
+// The type parameters are matched with a `typeparam[constraint]` pseudo type,
+// like `typeparam[comparable]`; `typeparam` without a constraint matches any type parameter.
 func (ExprType) Is(typ string) bool { return boolResult }
 
 // HasPointers reports whether a type contains at least one pointer.
@@ -328,6 +492,59 @@ type File struct {
 // Imports reports whether the current file imports the given path.
 func (File) Imports(path string) bool { return boolResult }
 
+// Func represents the enclosing function declaration.
+type Func struct {
+	// Name is a function (or method) name.
+	Name String
+
+	// IsMethod reports whether the function has a receiver.
+	IsMethod bool
+
+	// IsTest reports whether the function is a Test, Benchmark,
+	// Fuzz or Example function declared inside a _test.go file.
+	IsTest bool
+
+	// Receiver describes the method receiver.
+	// For functions without a receiver, all Receiver conditions are false.
+	Receiver FuncReceiver
+
+	// Results describes the function result types.
+	Results FuncResults
+}
+
+// FuncReceiver describes the method receiver.
+type FuncReceiver struct {
+	// Type is a receiver type.
+	Type FuncReceiverType
+}
+
+// FuncReceiverType is a method receiver type.
+type FuncReceiverType struct{}
+
+// Is reports whether a receiver type is identical to a given type.
+// Works like ExprType.Is method.
+//
+// Note that pointer receivers should be matched as pointers: `*bytes.Buffer`.
+func (FuncReceiverType) Is(typ string) bool { return boolResult }
+
+// FuncResults describes the function result types.
+type FuncResults struct{}
+
+// Contains reports whether any of the function results has a given type.
+// The typ is matched like in ExprType.Is method.
+//
+// Example: m.Func().Results.Contains("error")
+func (FuncResults) Contains(typ string) bool { return boolResult }
+
+// PackageMatches describes a group of the package-scoped rule matches.
+type PackageMatches struct {
+	// Count is a number of matches inside the group.
+	Count int
+
+	// Files is a number of distinct files the group matches come from.
+	Files int
+}
+
 // GoVersion is an analysis target go language version.
 // It can be compared to Go versions like "1.10", "1.16" using
 // the associated methods.
@@ -1,6 +1,8 @@
 package dsl
 
 import (
+	"github.com/quasilyte/go-ruleguard/dsl/ast"
+	"github.com/quasilyte/go-ruleguard/dsl/constant"
 	"github.com/quasilyte/go-ruleguard/dsl/types"
 )
 
@@ -9,6 +11,14 @@ import (
 type VarFilterContext struct {
 	// Type is mapped to Var.Type field.
 	Type types.Type
+
+	// Node is a matched Var expression syntax tree.
+	// It's nil if Var is not an expression.
+	Node ast.Expr
+
+	// Value is a matched Var constant value.
+	// If Var is not a constant expression, its Kind() is constant.Unknown.
+	Value constant.Value
 }
 
 // SizeOf returns the size of the given type.
@@ -19,3 +19,18 @@ func AsStruct(x Type) *Struct { return nil }
 // AsInterface is a type-assert like operation, x.(*Interface), but never panics.
 // Returns nil if type is not an interface.
 func AsInterface(x Type) *Interface { return nil }
+
+// AsNamed is a type-assert like operation, x.(*Named), but never panics.
+// Returns nil if type is not a named type.
+func AsNamed(x Type) *Named { return nil }
+
+// AsTypeParam is a type-assert like operation, x.(*TypeParam), but never panics.
+// Returns nil if type is not a type parameter.
+func AsTypeParam(x Type) *TypeParam { return nil }
+
+// StructTagGet returns the value associated with key in the struct tag string.
+// If there is no such key in the tag, it returns the empty string.
+// It works like the reflect.StructTag.Get method.
+//
+// Example: StructTagGet(`json:"name,omitempty"`, "json") => "name,omitempty"
+func StructTagGet(tag, key string) string { return "" }
@@ -9,9 +9,13 @@ func (*Slice) String() string     { return "" }
 func (*Pointer) String() string   { return "" }
 func (*Interface) String() string { return "" }
 func (*Struct) String() string    { return "" }
+func (*Named) String() string     { return "" }
+func (*TypeParam) String() string { return "" }
 
 func (*Array) Underlying() Type     { return nil }
 func (*Slice) Underlying() Type     { return nil }
 func (*Pointer) Underlying() Type   { return nil }
 func (*Interface) Underlying() Type { return nil }
 func (*Struct) Underlying() Type    { return nil }
+func (*Named) Underlying() Type     { return nil }
+func (*TypeParam) Underlying() Type { return nil }
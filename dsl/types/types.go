@@ -32,6 +32,12 @@ type (
 
 	// A struct represents a struct type.
 	Struct struct{}
+
+	// A Named represents a named (defined) type.
+	Named struct{}
+
+	// A TypeParam represents a type parameter type.
+	TypeParam struct{}
 )
 
 // NewArray returns a new array type for the given element type and length.
@@ -61,8 +67,87 @@ func (*Struct) NumFields() int { return 0 }
 
 func (*Struct) Field(i int) *Var { return nil }
 
+// Tag returns the i'th field tag for 0 <= i < NumFields().
+// Use StructTagGet to get the value associated with a tag key.
+func (*Struct) Tag(i int) string { return "" }
+
+// Obj returns the type name for the declaration defining the named type.
+func (*Named) Obj() *TypeName { return nil }
+
+// NumMethods returns the number of explicit methods defined for the named type.
+// Both value and pointer receiver methods are counted,
+// promoted methods of the embedded fields are not.
+func (*Named) NumMethods() int { return 0 }
+
+// Method returns the i'th method of named type for 0 <= i < NumMethods().
+func (*Named) Method(i int) *Func { return nil }
+
+// TypeParams returns the type parameters of the named type.
+// The result is never nil, but it can be empty.
+func (*Named) TypeParams() *TypeParamList { return nil }
+
+// Obj returns the type name for the type parameter.
+func (*TypeParam) Obj() *TypeName { return nil }
+
+// Index returns the index of the type param within its param list.
+func (*TypeParam) Index() int { return 0 }
+
+// Constraint returns the type constraint specified for the type parameter.
+func (*TypeParam) Constraint() Type { return nil }
+
+// TypeParamList holds a list of type parameters.
+type TypeParamList struct{}
+
+// Len returns the number of type parameters in the list.
+func (*TypeParamList) Len() int { return 0 }
+
+// At returns the i'th type parameter in the list.
+func (*TypeParamList) At(i int) *TypeParam { return nil }
+
+// A Var represents a declared variable (including function parameters and results, and struct fields).
 type Var struct{}
 
+// Name returns the variable name.
+// For the embedded fields, it's the type name.
+func (*Var) Name() string { return "" }
+
+// Exported reports whether the variable name starts with a capital letter.
+func (*Var) Exported() bool { return false }
+
 func (*Var) Embedded() bool { return false }
 
 func (*Var) Type() Type { return nil }
+
+// A Func represents a declared function or method.
+type Func struct{}
+
+// Name returns the function name.
+func (*Func) Name() string { return "" }
+
+// Exported reports whether the function name starts with a capital letter.
+func (*Func) Exported() bool { return false }
+
+// Type returns the function signature type.
+func (*Func) Type() Type { return nil }
+
+// A TypeName represents a name for a (defined or alias) type.
+type TypeName struct{}
+
+// Name returns the type name.
+func (*TypeName) Name() string { return "" }
+
+// Exported reports whether the type name starts with a capital letter.
+func (*TypeName) Exported() bool { return false }
+
+// Pkg returns the package the type is declared in.
+// It's nil for the predeclared types like error.
+func (*TypeName) Pkg() *Package { return nil }
+
+// A Package describes a Go package.
+type Package struct{}
+
+// Path returns the package path, like "encoding/json".
+func (*Package) Path() string { return "" }
+
+// Name returns the package name, like "json".
+func (*Package) Name() string { return "" }
@@ -7,7 +7,7 @@ toolchain go1.23.6
 require (
 	github.com/go-toolsmith/astcopy v1.0.2
 	github.com/google/go-cmp v0.6.0
-	github.com/quasilyte/go-ruleguard/dsl v0.3.22
+	github.com/quasilyte/go-ruleguard/dsl v0.3.24
 	github.com/quasilyte/go-ruleguard/rules v0.0.0-20211022131956-028d6511ab71
 	github.com/quasilyte/gogrep v0.5.0
 	github.com/quasilyte/stdinfo v0.0.0-20220114132959-f7386bf02567
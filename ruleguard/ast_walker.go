@@ -345,9 +345,9 @@ func (w *astWalker) walk(n ast.Node) {
 		}
 
 	case *ast.FuncDecl:
-		w.visit(n, nodetag.FuncDecl)
 		prevFunc := w.filterParams.currentFunc
 		w.filterParams.currentFunc = n
+		w.visit(n, nodetag.FuncDecl)
 		if n.Doc != nil {
 			w.walk(n.Doc)
 		}
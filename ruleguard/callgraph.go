@@ -0,0 +1,152 @@
+package ruleguard
+
+import (
+	"go/ast"
+	"go/types"
+
+	"golang.org/x/tools/go/ast/astutil"
+)
+
+// callGraph is a static call map of the package functions.
+//
+// It's built lazily from the files given to the engine, so
+// the rules that don't use Object.Calls() filter don't pay for it.
+//
+// Only the static calls are recorded: calls of the functions
+// and the concrete type methods. Interface method calls and
+// calls through the function values are not followed.
+// A function literal body is considered to be a part of the
+// enclosing function only if that literal is called immediately,
+// like in `defer func() { ... }()`.
+// So the graph is an under-approximation: Calls() can return
+// false for a function that calls the target dynamically.
+type callGraph struct {
+	info  *types.Info
+	files []*ast.File
+
+	built bool
+
+	// calls maps every package function to the functions it calls.
+	calls map[*types.Func][]*types.Func
+
+	cache map[callGraphKey]bool
+}
+
+type callGraphKey struct {
+	fn     *types.Func
+	target string
+}
+
+func newCallGraph(info *types.Info, files []*ast.File) *callGraph {
+	return &callGraph{info: info, files: files}
+}
+
+// Calls reports whether fn calls a function with the specified full name,
+// directly or through other functions of the same package.
+//
+// See types.Func.FullName() for the function names format.
+func (g *callGraph) Calls(fn *types.Func, target string) bool {
+	if !g.built {
+		g.build()
+	}
+	key := callGraphKey{fn: fn.Origin(), target: target}
+	if result, ok := g.cache[key]; ok {
+		return result
+	}
+	result := g.walk(key.fn, target, make(map[*types.Func]bool))
+	g.cache[key] = result
+	return result
+}
+
+func (g *callGraph) walk(fn *types.Func, target string, visited map[*types.Func]bool) bool {
+	if visited[fn] {
+		return false
+	}
+	visited[fn] = true
+	for _, callee := range g.calls[fn] {
+		if callee.FullName() == target {
+			return true
+		}
+		// Functions from the other packages don't have an entry
+		// inside the calls map, so they're not followed.
+		if g.walk(callee, target, visited) {
+			return true
+		}
+	}
+	return false
+}
+
+func (g *callGraph) build() {
+	g.built = true
+	g.calls = make(map[*types.Func][]*types.Func)
+	g.cache = make(map[callGraphKey]bool)
+	for _, f := range g.files {
+		for _, decl := range f.Decls {
+			decl, ok := decl.(*ast.FuncDecl)
+			if !ok || decl.Body == nil {
+				continue
+			}
+			fn, ok := g.info.Defs[decl.Name].(*types.Func)
+			if !ok {
+				continue
+			}
+			g.calls[fn] = g.collectCalls(decl.Body)
+		}
+	}
+}
+
+func (g *callGraph) collectCalls(body *ast.BlockStmt) []*types.Func {
+	var callees []*types.Func
+	var calledLits map[*ast.FuncLit]bool
+	ast.Inspect(body, func(n ast.Node) bool {
+		switch n := n.(type) {
+		case *ast.FuncLit:
+			return calledLits[n]
+		case *ast.CallExpr:
+			if lit, ok := astutil.Unparen(n.Fun).(*ast.FuncLit); ok {
+				if calledLits == nil {
+					calledLits = make(map[*ast.FuncLit]bool)
+				}
+				calledLits[lit] = true
+				return true
+			}
+			if callee := g.staticCallee(n); callee != nil {
+				callees = append(callees, callee)
+			}
+		}
+		return true
+	})
+	return callees
+}
+
+// staticCallee returns a function that is called by the call expression.
+// If the callee can't be resolved statically, nil is returned.
+func (g *callGraph) staticCallee(call *ast.CallExpr) *types.Func {
+	fnExpr := astutil.Unparen(call.Fun)
+	// Unwrap the explicit generic function instantiation.
+	switch e := fnExpr.(type) {
+	case *ast.IndexExpr:
+		fnExpr = e.X
+	case *ast.IndexListExpr:
+		fnExpr = e.X
+	}
+
+	var obj types.Object
+	switch e := fnExpr.(type) {
+	case *ast.Ident:
+		obj = g.info.Uses[e]
+	case *ast.SelectorExpr:
+		obj = g.info.Uses[e.Sel]
+	}
+	fn, ok := obj.(*types.Func)
+	if !ok {
+		// A builtin, a type conversion or a function value call.
+		return nil
+	}
+	sig := fn.Type().(*types.Signature)
+	if sig.Recv() != nil && types.IsInterface(sig.Recv().Type()) {
+		// An interface method (including the type param methods).
+		return nil
+	}
+	return fn.Origin()
+}
@@ -0,0 +1,219 @@
+package ruleguard
+
+import (
+	"go/ast"
+	"go/types"
+	"sort"
+
+	"github.com/quasilyte/gogrep"
+	"golang.org/x/tools/go/cfg"
+)
+
+// funcCFG is a control flow graph of a single function body.
+//
+// Note that the CFG doesn't descend into the function literals,
+// every function literal body has its own graph.
+type funcCFG struct {
+	blocks []*cfg.Block
+
+	// nodes are all CFG blocks nodes sorted by their positions.
+	// These nodes don't overlap.
+	nodes []cfgNode
+}
+
+type cfgNode struct {
+	node  ast.Node
+	block *cfg.Block
+	index int
+}
+
+// noReturnFuncs are the functions that never return.
+// They're used to cut the CFG paths, so the code after
+// `log.Fatal()` call is unreachable.
+var noReturnFuncs = map[string]bool{
+	"os.Exit":                   true,
+	"runtime.Goexit":            true,
+	"log.Fatal":                 true,
+	"log.Fatalf":                true,
+	"log.Fatalln":               true,
+	"log.Panic":                 true,
+	"log.Panicf":                true,
+	"log.Panicln":               true,
+	"(*log.Logger).Fatal":       true,
+	"(*log.Logger).Fatalf":      true,
+	"(*log.Logger).Fatalln":     true,
+	"(*log.Logger).Panic":       true,
+	"(*log.Logger).Panicf":      true,
+	"(*log.Logger).Panicln":     true,
+	"(*testing.common).Fatal":   true,
+	"(*testing.common).Fatalf":  true,
+	"(*testing.common).FailNow": true,
+	"(*testing.common).Skip":    true,
+	"(*testing.common).Skipf":   true,
+	"(*testing.common).SkipNow": true,
+}
+
+func newFuncCFG(info *types.Info, body *ast.BlockStmt) *funcCFG {
+	mayReturn := func(call *ast.CallExpr) bool {
+		return !isNoReturnCall(info, call)
+	}
+	g := cfg.New(body, mayReturn)
+	fg := &funcCFG{blocks: g.Blocks}
+	for _, b := range g.Blocks {
+		for i, n := range b.Nodes {
+			fg.nodes = append(fg.nodes, cfgNode{node: n, block: b, index: i})
+		}
+	}
+	sort.Slice(fg.nodes, func(i, j int) bool {
+		return fg.nodes[i].node.Pos() < fg.nodes[j].node.Pos()
+	})
+	return fg
+}
+
+// lookup finds a CFG node that contains n.
+//
+// Control statements like `if` are not a part of the CFG.
+// If outer is true and n is not contained by any CFG node,
+// the first CFG node contained by n is returned instead.
+func (g *funcCFG) lookup(n ast.Node, outer bool) (cfgNode, bool) {
+	i := sort.Search(len(g.nodes), func(i int) bool {
+		return g.nodes[i].node.End() > n.Pos()
+	})
+	if i == len(g.nodes) {
+		return cfgNode{}, false
+	}
+	x := g.nodes[i]
+	if x.node.Pos() <= n.Pos() && n.End() <= x.node.End() {
+		return x, true
+	}
+	if outer && n.Pos() <= x.node.Pos() && x.node.End() <= n.End() {
+		return x, true
+	}
+	return cfgNode{}, false
+}
+
+// after returns a CFG location right after the n statement:
+// a block and its nodes that are executed next.
+func (g *funcCFG) after(n ast.Node) (*cfg.Block, []ast.Node, bool) {
+	if x, ok := g.lookup(n, false); ok {
+		return x.block, x.block.Nodes[x.index+1:], true
+	}
+	// Control statements are not a part of the CFG,
+	// but they have a dedicated block for the code that follows them.
+	for _, b := range g.blocks {
+		if b.Stmt != n {
+			continue
+		}
+		switch b.Kind {
+		case cfg.KindIfDone, cfg.KindForDone, cfg.KindRangeDone, cfg.KindSwitchDone, cfg.KindSelectDone:
+			return b, b.Nodes, true
+		}
+	}
+	return nil, nil, false
+}
+
+// alwaysReaches reports whether every path from the given location
+// to the function exit goes through a node that satisfies the pred.
+//
+// The paths that end with a no-return call like panic() are not considered.
+func (g *funcCFG) alwaysReaches(start *cfg.Block, nodes []ast.Node, pred func(ast.Node) bool) bool {
+	visited := make(map[*cfg.Block]bool)
+	var walk func(b *cfg.Block, nodes []ast.Node) bool
+	walk = func(b *cfg.Block, nodes []ast.Node) bool {
+		for _, n := range nodes {
+			if pred(n) {
+				return true
+			}
+		}
+		if len(b.Succs) == 0 {
+			return b.Return() == nil
+		}
+		for _, succ := range b.Succs {
+			if visited[succ] {
+				continue
+			}
+			visited[succ] = true
+			if !walk(succ, succ.Nodes) {
+				return false
+			}
+		}
+		return true
+	}
+	return walk(start, nodes)
+}
+
+func isNoReturnCall(info *types.Info, call *ast.CallExpr) bool {
+	var obj types.Object
+	switch fn := call.Fun.(type) {
+	case *ast.Ident:
+		obj = info.Uses[fn]
+	case *ast.SelectorExpr:
+		obj = info.Uses[fn.Sel]
+	}
+	switch obj := obj.(type) {
+	case *types.Builtin:
+		return obj.Name() == "panic"
+	case *types.Func:
+		return noReturnFuncs[obj.FullName()]
+	default:
+		return false
+	}
+}
+
+// funcCFG returns a CFG of the innermost function that contains n.
+// The second result is that function node.
+func (params *filterParams) funcCFG(n ast.Node) (*funcCFG, ast.Node) {
+	var fn ast.Node
+	var body *ast.BlockStmt
+	target := n
+	params.walkAncestors(n, func(n, child ast.Node) bool {
+		if n == target {
+			// n can be a part of the node path if it's
+			// an ancestor of the current match.
+			return true
+		}
+		switch n := n.(type) {
+		case *ast.FuncLit:
+			fn, body = n, n.Body
+		case *ast.FuncDecl:
+			fn, body = n, n.Body
+		default:
+			return true
+		}
+		return false
+	})
+	if body == nil {
+		return nil, nil
+	}
+	g := params.cfgCache[body]
+	if g == nil {
+		if params.cfgCache == nil {
+			params.cfgCache = make(map[*ast.BlockStmt]*funcCFG)
+		}
+		g = newFuncCFG(params.ctx.Types, body)
+		params.cfgCache[body] = g
+	}
+	return g, fn
+}
+
+// isUnreachable reports whether n can't be reached from its function entry.
+// Function literals inside the unreachable code are unreachable too.
+func (params *filterParams) isUnreachable(n ast.Node) bool {
+	if slice, ok := n.(*gogrep.NodeSlice); ok {
+		if slice.Len() == 0 {
+			return false
+		}
+		n = slice.At(0)
+	}
+	g, fn := params.funcCFG(n)
+	if g == nil {
+		return false
+	}
+	if x, ok := g.lookup(n, true); ok && !x.block.Live {
+		return true
+	}
+	if lit, ok := fn.(*ast.FuncLit); ok {
+		return params.isUnreachable(lit)
+	}
+	return false
+}
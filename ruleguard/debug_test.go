@@ -261,3 +261,61 @@ func newDebugTestRunner(input string) (*debugTestRunner, error) {
 	runner.ctx = ctx
 	return runner, nil
 }
+
+func TestUserFuncPanics(t *testing.T) {
+	rules := `
+	package gorules
+	import "github.com/quasilyte/go-ruleguard/dsl"
+	func depth(n int) int { return depth(n + 1) }
+	func recursiveFilter(ctx *dsl.VarFilterContext) bool { return depth(0) == 0 }
+	func recursiveDo(ctx *dsl.DoContext) {
+		if depth(0) == 0 {
+			ctx.SetReport("unreachable")
+		}
+	}
+	func divFilter(ctx *dsl.VarFilterContext) bool {
+		n := 0
+		return 10/n == 0
+	}
+	func testrule(m dsl.Matcher) {
+		m.Match("f($x)").Where(m["x"].Filter(recursiveFilter)).Report("filter")
+		m.Match("f()").Do(recursiveDo)
+		m.Match("f($x, $_, $_)").Where(m["x"].Filter(divFilter)).Report("div")
+		m.Match("f($_, $_)").Report("ok")
+	}`
+
+	e := NewEngine()
+	ctx := &LoadContext{
+		Fset: token.NewFileSet(),
+	}
+	if err := e.Load(ctx, "rules.go", strings.NewReader(rules)); err != nil {
+		t.Fatal(err)
+	}
+	runner, err := newDebugTestRunner("f(1); f(); f(1, 2, 3); f(1, 2)")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var reports []string
+	runner.ctx.Report = func(data *ReportData) {
+		reports = append(reports, data.Message)
+	}
+	if err := runner.Run(t, e); err != nil {
+		t.Fatal(err)
+	}
+
+	// The runaway recursion and the runtime errors reject the match
+	// instead of crashing the program; other rules are executed as usual.
+	wantDebug := []string{
+		`input.go:4: [rules.go:16] rejected by m["x"].Filter(recursiveFilter): quasigo: gorules.depth: max call depth (256) exceeded`,
+		`  $x int: 1`,
+		`input.go:4: [rules.go:17] rejected by Do: quasigo: gorules.depth: max call depth (256) exceeded`,
+		`input.go:4: [rules.go:18] rejected by m["x"].Filter(divFilter): runtime error: integer divide by zero`,
+		`  $x int: 1`,
+	}
+	if diff := cmp.Diff(wantDebug, runner.out); diff != "" {
+		t.Errorf("debug output mismatch (-want +have):\n%s", diff)
+	}
+	if diff := cmp.Diff([]string{"ok"}, reports); diff != "" {
+		t.Errorf("reports mismatch (-want +have):\n%s", diff)
+	}
+}
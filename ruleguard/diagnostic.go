@@ -0,0 +1,132 @@
+package ruleguard
+
+import (
+	"go/ast"
+	"go/build"
+	"go/token"
+	"sort"
+)
+
+// Diagnostic is a self-contained description of the reported issue.
+//
+// Unlike ReportData, it doesn't reference the AST or the token.FileSet,
+// so it can be freely kept around, sorted and serialized.
+type Diagnostic struct {
+	// From and To describe the reported source code range.
+	From token.Position
+	To   token.Position
+
+	// Group is a name of the rule group that reported this issue.
+	Group string
+
+	// RulesFile and RuleLine describe the location of the matched rule.
+	RulesFile string
+	RuleLine  int
+
+	Message  string
+	Severity Severity
+
+	// Fingerprint is copied from the ReportData.Fingerprint.
+	// It's empty unless RunContext.Fingerprints is set.
+	Fingerprint string
+
+	// Suggestions are independent fixes for this issue, see ReportData.Suggestions.
+	Suggestions []DiagnosticSuggestion
+}
+
+// DiagnosticSuggestion is a resolved version of the Suggestion.
+type DiagnosticSuggestion struct {
+	Label string
+
+	// Edits should be applied together.
+	// The first edit is always the main Suggestion replacement.
+	Edits []DiagnosticEdit
+}
+
+// DiagnosticEdit replaces the From-To source range with Replacement.
+type DiagnosticEdit struct {
+	From        token.Position
+	To          token.Position
+	Replacement string
+}
+
+// NewDiagnostic creates a Diagnostic from the report data.
+// All positions are resolved using the fset.
+func NewDiagnostic(fset *token.FileSet, data *ReportData) Diagnostic {
+	d := Diagnostic{
+		From:        fset.Position(data.Node.Pos()),
+		To:          fset.Position(data.Node.End()),
+		Group:       data.RuleInfo.Group.Name,
+		RulesFile:   data.RuleInfo.Group.Filename,
+		RuleLine:    data.RuleInfo.Line,
+		Message:     data.Message,
+		Severity:    data.Severity,
+		Fingerprint: data.Fingerprint,
+	}
+	for _, s := range data.Suggestions {
+		resolved := DiagnosticSuggestion{
+			Label: s.Label,
+			Edits: make([]DiagnosticEdit, 0, len(s.Edits)+1),
+		}
+		resolved.Edits = append(resolved.Edits, DiagnosticEdit{
+			From:        fset.Position(s.From),
+			To:          fset.Position(s.To),
+			Replacement: string(s.Replacement),
+		})
+		for _, e := range s.Edits {
+			resolved.Edits = append(resolved.Edits, DiagnosticEdit{
+				From:        fset.Position(e.From),
+				To:          fset.Position(e.To),
+				Replacement: string(e.Replacement),
+			})
+		}
+		d.Suggestions = append(d.Suggestions, resolved)
+	}
+	return d
+}
+
+// SortDiagnostics sorts the diagnostics by their position.
+// Diagnostics for the same position are ordered by their rule location.
+func SortDiagnostics(list []Diagnostic) {
+	sort.SliceStable(list, func(i, j int) bool {
+		x := list[i]
+		y := list[j]
+		if x.From.Filename != y.From.Filename {
+			return x.From.Filename < y.From.Filename
+		}
+		if x.From.Offset != y.From.Offset {
+			return x.From.Offset < y.From.Offset
+		}
+		if x.Group != y.Group {
+			return x.Group < y.Group
+		}
+		if x.RuleLine != y.RuleLine {
+			return x.RuleLine < y.RuleLine
+		}
+		return x.Message < y.Message
+	})
+}
+
+// Diagnostics converts all reports into the diagnostics.
+// The result order matches the Reports order.
+func (result *RunPackagesResult) Diagnostics() []Diagnostic {
+	list := make([]Diagnostic, len(result.Reports))
+	for i := range result.Reports {
+		list[i] = NewDiagnostic(result.Fset, &result.Reports[i])
+	}
+	return list
+}
+
+func (e *engine) CollectDiagnostics(ctx *RunContext, buildContext *build.Context, files []*ast.File) ([]Diagnostic, error) {
+	// Don't modify the caller-provided context.
+	collectCtx := *ctx
+	var list []Diagnostic
+	collectCtx.Report = func(data *ReportData) {
+		list = append(list, NewDiagnostic(ctx.Fset, data))
+	}
+	if err := e.RunPackage(&collectCtx, buildContext, files); err != nil {
+		return nil, err
+	}
+	SortDiagnostics(list)
+	return list, nil
+}
@@ -0,0 +1,115 @@
+package ruleguard
+
+import (
+	"go/ast"
+	"go/importer"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestCollectDiagnostics(t *testing.T) {
+	rules := `
+	package gorules
+
+	import "github.com/quasilyte/go-ruleguard/dsl"
+
+	//doc:severity error
+	func swappedHasPrefix(m dsl.Matcher) {
+		m.Match("strings.HasPrefix($prefix, $s)").
+			Where(m["prefix"].Const && !m["s"].Const).
+			Report("suspicious args order").
+			SuggestAt(m["prefix"], "$s").
+			SuggestAt(m["s"], "$prefix")
+	}
+
+	func printlnCall(m dsl.Matcher) {
+		m.Match("println($*_)").Report("println call")
+	}
+	`
+	src := `package example
+
+import "strings"
+
+func f(s string) bool {
+	return strings.HasPrefix("http://", s)
+}
+
+func g() { println() }
+`
+
+	e := NewEngine()
+	if err := e.Load(&LoadContext{Fset: token.NewFileSet()}, "rules.go", strings.NewReader(rules)); err != nil {
+		t.Fatalf("load rules: %v", err)
+	}
+
+	fset := token.NewFileSet()
+	f, err := parser.ParseFile(fset, "example.go", src, parser.ParseComments)
+	if err != nil {
+		t.Fatalf("parse: %v", err)
+	}
+	typesInfo := &types.Info{
+		Types: map[ast.Expr]types.TypeAndValue{},
+		Uses:  map[*ast.Ident]types.Object{},
+		Defs:  map[*ast.Ident]types.Object{},
+	}
+	typechecker := types.Config{Importer: importer.ForCompiler(fset, "source", nil)}
+	pkg, err := typechecker.Check("example", fset, []*ast.File{f}, typesInfo)
+	if err != nil {
+		t.Fatalf("typecheck: %v", err)
+	}
+
+	ctx := &RunContext{
+		Pkg:   pkg,
+		Types: typesInfo,
+		Fset:  fset,
+	}
+	have, err := e.CollectDiagnostics(ctx, f)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if ctx.Report != nil {
+		t.Fatalf("ctx.Report is modified")
+	}
+
+	pos := func(line, column, offset int) token.Position {
+		return token.Position{Filename: "example.go", Line: line, Column: column, Offset: offset}
+	}
+	want := []Diagnostic{
+		{
+			From:      pos(6, 9, 67),
+			To:        pos(6, 40, 98),
+			Group:     "swappedHasPrefix",
+			RulesFile: "rules.go",
+			RuleLine:  8,
+			Message:   "suspicious args order",
+			Severity:  SeverityError,
+			Suggestions: []DiagnosticSuggestion{
+				{
+					Edits: []DiagnosticEdit{
+						{From: pos(6, 27, 85), To: pos(6, 36, 94), Replacement: "s"},
+						{From: pos(6, 38, 96), To: pos(6, 39, 97), Replacement: `"http://"`},
+					},
+				},
+			},
+		},
+		{
+			From:      pos(9, 12, 113),
+			To:        pos(9, 21, 122),
+			Group:     "printlnCall",
+			RulesFile: "rules.go",
+			RuleLine:  16,
+			Message:   "println call",
+			Severity:  SeverityWarning,
+		},
+	}
+	if diff := cmp.Diff(want, have, cmp.FilterPath(func(p cmp.Path) bool {
+		return p.Last().String() == ".Fingerprint"
+	}, cmp.Ignore())); diff != "" {
+		t.Errorf("diagnostics mismatch (-want +have):\n%s", diff)
+	}
+}
@@ -16,9 +16,14 @@ import (
 	"github.com/quasilyte/go-ruleguard/internal/goenv"
 	"github.com/quasilyte/go-ruleguard/ruleguard/ir"
 	"github.com/quasilyte/go-ruleguard/ruleguard/quasigo"
+	"github.com/quasilyte/go-ruleguard/ruleguard/quasigo/stdlib/qfilepath"
 	"github.com/quasilyte/go-ruleguard/ruleguard/quasigo/stdlib/qfmt"
+	"github.com/quasilyte/go-ruleguard/ruleguard/quasigo/stdlib/qpath"
+	"github.com/quasilyte/go-ruleguard/ruleguard/quasigo/stdlib/qregexp"
+	"github.com/quasilyte/go-ruleguard/ruleguard/quasigo/stdlib/qsort"
 	"github.com/quasilyte/go-ruleguard/ruleguard/quasigo/stdlib/qstrconv"
 	"github.com/quasilyte/go-ruleguard/ruleguard/quasigo/stdlib/qstrings"
+	"github.com/quasilyte/go-ruleguard/ruleguard/quasigo/stdlib/qunicode"
 	"github.com/quasilyte/go-ruleguard/ruleguard/typematch"
 	"github.com/quasilyte/stdinfo"
 )
@@ -46,6 +51,10 @@ func (e *engine) LoadedGroups() []GoRuleGroup {
 	return result
 }
 
+func (e *engine) HasPackageRules() bool {
+	return e.ruleSet != nil && e.ruleSet.hasPackageRules
+}
+
 func (e *engine) Load(ctx *LoadContext, buildContext *build.Context, filename string, r io.Reader) error {
 	data, err := io.ReadAll(r)
 	if err != nil {
@@ -126,7 +135,30 @@ func (e *engine) Run(ctx *RunContext, buildContext *build.Context, f *ast.File)
 		return errors.New("used Run() with an empty rule set; forgot to call Load() first?")
 	}
 	rset := e.ruleSet
-	return newRulesRunner(ctx, buildContext, e.state, rset).run(f)
+	rr := newRulesRunner(ctx, buildContext, e.state, rset)
+	rr.filterParams.callGraph = newCallGraph(ctx.Types, []*ast.File{f})
+	return rr.run(f)
+}
+
+func (e *engine) RunPackage(ctx *RunContext, buildContext *build.Context, files []*ast.File) error {
+	if e.ruleSet == nil {
+		return errors.New("used RunPackage() with an empty rule set; forgot to call Load() first?")
+	}
+	pkgMatches := newPackageMatches()
+	graph := newCallGraph(ctx.Types, files)
+	for _, f := range files {
+		rr := newRulesRunner(ctx, buildContext, e.state, e.ruleSet)
+		rr.pkgMatches = pkgMatches
+		rr.filterParams.callGraph = graph
+		if err := rr.run(f); err != nil {
+			return err
+		}
+	}
+	pkgMatches.report(ctx)
+	if ctx.ReportUnusedSuppression != nil {
+		pkgMatches.reportUnusedSuppressions(ctx, e.ruleSet)
+	}
+	return nil
 }
 
 // engineState is a shared state inside the engine.
@@ -147,6 +179,11 @@ func newEngineState() *engineState {
 	qstrings.ImportAll(env)
 	qstrconv.ImportAll(env)
 	qfmt.ImportAll(env)
+	qunicode.ImportAll(env)
+	qregexp.ImportAll(env)
+	qpath.ImportAll(env)
+	qfilepath.ImportAll(env)
+	qsort.ImportAll(env)
 	state := &engineState{
 		env:       env,
 		pkgCache:  make(map[string]*types.Package),
@@ -6,6 +6,7 @@ import (
 	"go/token"
 	"go/types"
 	"path/filepath"
+	"runtime"
 
 	"github.com/quasilyte/gogrep"
 	"github.com/quasilyte/gogrep/nodetag"
@@ -68,7 +69,7 @@ func makeOrFilter(lhs, rhs matchFilter) filterFunc {
 
 func makeDeadcodeFilter(src string) filterFunc {
 	return func(params *filterParams) matchFilterResult {
-		if params.deadcode {
+		if params.deadcode || params.isUnreachable(params.match.Node()) {
 			return filterSuccess
 		}
 		return filterFailure(src)
@@ -104,6 +105,82 @@ func makeFileNameMatchesFilter(src string, re textmatch.Pattern) filterFunc {
 	}
 }
 
+func makeFuncNameMatchesFilter(src string, re textmatch.Pattern) filterFunc {
+	return func(params *filterParams) matchFilterResult {
+		if params.currentFunc != nil && re.MatchString(params.currentFunc.Name.Name) {
+			return filterSuccess
+		}
+		return filterFailure(src)
+	}
+}
+
+func makeFuncIsMethodFilter(src string) filterFunc {
+	return func(params *filterParams) matchFilterResult {
+		if params.currentFunc != nil && params.currentFunc.Recv != nil {
+			return filterSuccess
+		}
+		return filterFailure(src)
+	}
+}
+
+func makeFuncIsTestFilter(src string) filterFunc {
+	return func(params *filterParams) matchFilterResult {
+		if params.currentFunc != nil && isTestFunc(params.filename, params.currentFunc) {
+			return filterSuccess
+		}
+		return filterFailure(src)
+	}
+}
+
+func makeFuncReceiverTypeIsFilter(src string, pat *typematch.Pattern) filterFunc {
+	return func(params *filterParams) matchFilterResult {
+		sig := params.currentFuncSignature()
+		if sig == nil || sig.Recv() == nil {
+			return filterFailure(src)
+		}
+		if pat.MatchIdentical(params.typematchState, sig.Recv().Type()) {
+			return filterSuccess
+		}
+		return filterFailure(src)
+	}
+}
+
+func makeFuncResultsContainsFilter(src string, pat *typematch.Pattern) filterFunc {
+	return func(params *filterParams) matchFilterResult {
+		sig := params.currentFuncSignature()
+		if sig == nil {
+			return filterFailure(src)
+		}
+		results := sig.Results()
+		for i := 0; i < results.Len(); i++ {
+			if pat.MatchIdentical(params.typematchState, results.At(i).Type()) {
+				return filterSuccess
+			}
+		}
+		return filterFailure(src)
+	}
+}
+
+func makeMatchesCountConstFilter(src string, op token.Token, rhsValue constant.Value) filterFunc {
+	return func(params *filterParams) matchFilterResult {
+		lhsValue := constant.MakeInt64(int64(len(params.packageMatches.matches)))
+		if constant.Compare(lhsValue, op, rhsValue) {
+			return filterSuccess
+		}
+		return filterFailure(src)
+	}
+}
+
+func makeMatchesFilesConstFilter(src string, op token.Token, rhsValue constant.Value) filterFunc {
+	return func(params *filterParams) matchFilterResult {
+		lhsValue := constant.MakeInt64(int64(params.packageMatches.numFiles))
+		if constant.Compare(lhsValue, op, rhsValue) {
+			return filterSuccess
+		}
+		return filterFailure(src)
+	}
+}
+
 func makePureFilter(src, varname string) filterFunc {
 	return func(params *filterParams) matchFilterResult {
 		if list := asExprSlice(params.subNode(varname)); list != nil {
@@ -202,13 +279,90 @@ func makeVarContainsFilter(src, varname string, pat *gogrep.Pattern) filterFunc
 	}
 }
 
+func makeVarNotContainsFilter(src, varname string, pat *gogrep.Pattern) filterFunc {
+	containsFilter := makeVarContainsFilter(src, varname, pat)
+	return func(params *filterParams) matchFilterResult {
+		if containsFilter(params).Matched() {
+			return filterFailure(src)
+		}
+		return filterSuccess
+	}
+}
+
+func makeVarSiblingStmtFilter(src, varname string, followed bool, pat *gogrep.Pattern) filterFunc {
+	return func(params *filterParams) matchFilterResult {
+		list, index := findSiblingStmts(params, params.subNode(varname), followed)
+		if list == nil {
+			return filterFailure(src)
+		}
+		var siblings []ast.Stmt
+		if followed {
+			siblings = list[index+1:]
+		} else {
+			siblings = list[:index]
+		}
+		params.gogrepSubState.CapturePreset = params.match.CaptureList()
+		matched := false
+		for _, stmt := range siblings {
+			var n ast.Node = stmt
+			if stmt, ok := stmt.(*ast.ExprStmt); ok {
+				// Allow expression patterns like `$f.Close()`.
+				n = stmt.X
+			}
+			pat.MatchNode(params.gogrepSubState, n, func(m gogrep.MatchData) {
+				matched = true
+			})
+			if matched {
+				return filterSuccess
+			}
+		}
+		return filterFailure(src)
+	}
+}
+
+func makeVarAlwaysFollowedByFilter(src, varname string, pat *gogrep.Pattern) filterFunc {
+	return func(params *filterParams) matchFilterResult {
+		n := params.subNode(varname)
+		if slice, ok := n.(*gogrep.NodeSlice); ok {
+			if slice.Len() == 0 {
+				return filterFailure(src)
+			}
+			n = slice.At(slice.Len() - 1)
+		}
+		g, _ := params.funcCFG(n)
+		if g == nil {
+			return filterFailure(src)
+		}
+		start, nodes, ok := g.after(n)
+		if !ok {
+			return filterFailure(src)
+		}
+		params.gogrepSubState.CapturePreset = params.match.CaptureList()
+		matches := func(n ast.Node) bool {
+			if stmt, ok := n.(*ast.ExprStmt); ok {
+				// Allow expression patterns like `$mu.Unlock()`.
+				n = stmt.X
+			}
+			matched := false
+			pat.MatchNode(params.gogrepSubState, n, func(m gogrep.MatchData) {
+				matched = true
+			})
+			return matched
+		}
+		if g.alwaysReaches(start, nodes, matches) {
+			return filterSuccess
+		}
+		return filterFailure(src)
+	}
+}
+
 func makeCustomVarFilter(src, varname string, fn *quasigo.Func) filterFunc {
 	return func(params *filterParams) matchFilterResult {
-		// TODO(quasilyte): what if bytecode function panics due to the programming error?
-		// We should probably catch the panic here, print trace and return "false"
-		// from the filter (or even propagate that panic to let it crash).
 		params.varname = varname
-		result := quasigo.Call(params.env, fn)
+		result, err := callUserFunc(params.env, fn)
+		if err != nil {
+			return filterFailure(src + ": " + err.Error())
+		}
 		if result.Value().(bool) {
 			return filterSuccess
 		}
@@ -216,6 +370,26 @@ func makeCustomVarFilter(src, varname string, fn *quasigo.Func) filterFunc {
 	}
 }
 
+// callUserFunc is like quasigo.Call, but it turns the call depth
+// overflow and runtime error panics (like an integer division by zero)
+// into an error, so a programming error inside the user func
+// fails the rule instead of crashing the program.
+func callUserFunc(env *quasigo.EvalEnv, fn *quasigo.Func) (result quasigo.CallResult, err error) {
+	defer func() {
+		if rv := recover(); rv != nil {
+			switch rv := rv.(type) {
+			case *quasigo.CallDepthError:
+				err = rv
+			case runtime.Error:
+				err = rv
+			default:
+				panic(rv)
+			}
+		}
+	}()
+	return quasigo.Call(env, fn), nil
+}
+
 func makeTypeImplementsFilter(src, varname string, iface *types.Interface) filterFunc {
 	return func(params *filterParams) matchFilterResult {
 		if list := asExprSlice(params.subNode(varname)); list != nil {
@@ -435,6 +609,49 @@ func makeObjectIsGlobalFilter(src, varname string) filterFunc {
 	}
 }
 
+func makeObjectDefinedByFilter(src, varname string, pat *gogrep.Pattern) filterFunc {
+	return func(params *filterParams) matchFilterResult {
+		id := identOf(params.subExpr(varname))
+		if id == nil {
+			return filterFailure(src)
+		}
+		obj, ok := params.ctx.Types.ObjectOf(id).(*types.Var)
+		if !ok || obj.IsField() {
+			return filterFailure(src)
+		}
+		def := findVarDefinition(params, obj)
+		if def == nil {
+			return filterFailure(src)
+		}
+		params.gogrepSubState.CapturePreset = params.match.CaptureList()
+		matched := false
+		pat.MatchNode(params.gogrepSubState, def, func(m gogrep.MatchData) {
+			matched = true
+		})
+		if matched {
+			return filterSuccess
+		}
+		return filterFailure(src)
+	}
+}
+
+func makeObjectCallsFilter(src, varname, target string) filterFunc {
+	return func(params *filterParams) matchFilterResult {
+		id := identOf(params.subExpr(varname))
+		if id == nil || params.callGraph == nil {
+			return filterFailure(src)
+		}
+		fn, ok := params.ctx.Types.ObjectOf(id).(*types.Func)
+		if !ok {
+			return filterFailure(src)
+		}
+		if params.callGraph.Calls(fn, target) {
+			return filterSuccess
+		}
+		return filterFailure(src)
+	}
+}
+
 func makeGoVersionFilter(src string, op token.Token, version GoVersion) filterFunc {
 	return func(params *filterParams) matchFilterResult {
 		if params.ctx.GoVersion.IsAny() {
@@ -597,6 +814,41 @@ func makeNodeIsFilter(src, varname string, tag nodetag.Value) filterFunc {
 	}
 }
 
+func makeNodeAncestorFilter(src, varname string, tag nodetag.Value) filterFunc {
+	return func(params *filterParams) matchFilterResult {
+		found := false
+		params.walkAncestors(params.subNode(varname), func(n, child ast.Node) bool {
+			found = nodeIs(n, tag)
+			return !found
+		})
+		if found {
+			return filterSuccess
+		}
+		return filterFailure(src)
+	}
+}
+
+func makeNodeInsideLoopFilter(src, varname string) filterFunc {
+	return func(params *filterParams) matchFilterResult {
+		insideLoop := false
+		params.walkAncestors(params.subNode(varname), func(n, child ast.Node) bool {
+			switch n := n.(type) {
+			case *ast.FuncLit, *ast.FuncDecl:
+				return false
+			case *ast.ForStmt:
+				insideLoop = child != n.Init
+			case *ast.RangeStmt:
+				insideLoop = child == n.Body
+			}
+			return !insideLoop
+		})
+		if insideLoop {
+			return filterSuccess
+		}
+		return filterFailure(src)
+	}
+}
+
 func makeObjectIsFilter(src, varname, objectName string) filterFunc {
 	var predicate func(types.Object) bool
 	switch objectName {
@@ -714,6 +966,119 @@ func typeHasPointers(typ types.Type) bool {
 	}
 }
 
+// findSiblingStmts finds a statement list that contains the statement with n.
+// The index is a position of that statement inside the list.
+//
+// If n is a statements slice, the index refers to its last
+// statement when last is true and to its first statement otherwise.
+func findSiblingStmts(params *filterParams, n ast.Node, last bool) ([]ast.Stmt, int) {
+	if slice, ok := n.(*gogrep.NodeSlice); ok && last && slice.Len() != 0 {
+		n = slice.At(slice.Len() - 1)
+	}
+	var list []ast.Stmt
+	var stmt ast.Node
+	params.walkAncestors(n, func(n, child ast.Node) bool {
+		switch n := n.(type) {
+		case *ast.BlockStmt:
+			list = n.List
+		case *ast.CaseClause:
+			list = n.Body
+		case *ast.CommClause:
+			list = n.Body
+		case *ast.FuncLit, *ast.FuncDecl:
+			return false
+		default:
+			return true
+		}
+		stmt = child
+		return false
+	})
+	for i, x := range list {
+		if x == stmt {
+			return list, i
+		}
+	}
+	return nil, 0
+}
+
+// findVarDefinition returns an expression that initializes obj
+// inside the current function declaration.
+//
+// We're conservative here: nil is returned if the variable is not
+// a local one, has no initializer, can be assigned more than
+// once or if its address is taken.
+func findVarDefinition(params *filterParams, obj *types.Var) ast.Expr {
+	fn := params.currentFunc
+	if fn == nil || fn.Body == nil {
+		return nil
+	}
+	if obj.Pos() < fn.Body.Pos() || obj.Pos() >= fn.Body.End() {
+		return nil
+	}
+
+	info := params.ctx.Types
+	isObj := func(e ast.Expr) bool {
+		id, ok := astutil.Unparen(e).(*ast.Ident)
+		return ok && info.ObjectOf(id) == obj
+	}
+
+	var def ast.Expr
+	reassigned := false
+	ast.Inspect(fn.Body, func(n ast.Node) bool {
+		if reassigned {
+			return false
+		}
+		switch n := n.(type) {
+		case *ast.AssignStmt:
+			for i, lhs := range n.Lhs {
+				if !isObj(lhs) {
+					continue
+				}
+				// For the := redeclarations, the ident is recorded in Uses.
+				if n.Tok != token.DEFINE || info.Defs[lhs.(*ast.Ident)] != obj {
+					reassigned = true
+					return false
+				}
+				def = assignedExpr(n.Rhs, len(n.Lhs), i)
+			}
+		case *ast.ValueSpec:
+			for i, name := range n.Names {
+				if info.Defs[name] == obj {
+					def = assignedExpr(n.Values, len(n.Names), i)
+				}
+			}
+		case *ast.RangeStmt:
+			if (n.Key != nil && isObj(n.Key)) || (n.Value != nil && isObj(n.Value)) {
+				reassigned = true
+			}
+		case *ast.IncDecStmt:
+			reassigned = isObj(n.X)
+		case *ast.UnaryExpr:
+			reassigned = n.Op == token.AND && isObj(n.X)
+		}
+		return !reassigned
+	})
+
+	if reassigned {
+		return nil
+	}
+	return def
+}
+
+// assignedExpr returns an expression that is assigned to the i-th
+// of numLHS assignment (or var declaration) operands.
+// For the tuple assignments like `x, err := f()` the tuple expr is returned.
+func assignedExpr(rhs []ast.Expr, numLHS, i int) ast.Expr {
+	switch {
+	case len(rhs) == numLHS:
+		return rhs[i]
+	case len(rhs) == 1:
+		return rhs[0]
+	default:
+		return nil
+	}
+}
+
 func findSinkRoot(params *filterParams) (ast.Node, *ast.KeyValueExpr) {
 	for i := 1; i < params.nodePath.Len(); i++ {
 		switch n := params.nodePath.NthParent(i).(type) {
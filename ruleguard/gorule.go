@@ -3,6 +3,7 @@ package ruleguard
 import (
 	"fmt"
 	"go/ast"
+	"go/constant"
 	"go/types"
 	"regexp"
 
@@ -16,6 +17,9 @@ type goRuleSet struct {
 	universal *scopedGoRuleSet
 
 	groups map[string]*GoRuleGroup // To handle redefinitions
+
+	// hasPackageRules is set if there are any package-scoped rules.
+	hasPackageRules bool
 }
 
 type scopedGoRuleSet struct {
@@ -31,14 +35,45 @@ type goCommentRule struct {
 }
 
 type goRule struct {
-	group      *GoRuleGroup
-	line       int
-	pat        *gogrep.Pattern
-	msg        string
-	location   string
-	suggestion string
-	filter     matchFilter
-	do         *quasigo.Func
+	group       *GoRuleGroup
+	line        int
+	pat         *gogrep.Pattern
+	msg         string
+	location    string
+	suggestions []goRuleSuggestion
+	filter      matchFilter
+	do          *quasigo.Func
+
+	// pkgScope is nil for the ordinary (file-scoped) rules.
+	pkgScope *packageScope
+}
+
+// packageScope describes a package-scoped rule, see Matcher.PackageWhere.
+// It's shared between all goRule objects created from the same ir.Rule,
+// so the matches of all rule alternatives are grouped together.
+type packageScope struct {
+	filter  matchFilter
+	groupBy []string
+}
+
+type goRuleSuggestion struct {
+	label    string
+	template string
+	edits    []goRuleSuggestionEdit
+
+	// imports lists packages that are referenced from the templates.
+	// They're added to the file imports if they're missing.
+	imports []goRuleSuggestionImport
+}
+
+type goRuleSuggestionEdit struct {
+	location string
+	template string
+}
+
+type goRuleSuggestionImport struct {
+	name string
+	path string
 }
 
 type matchFilterResult string
@@ -74,6 +109,15 @@ type filterParams struct {
 
 	currentFunc *ast.FuncDecl
 
+	// callGraph is built from the files given to the engine.
+	callGraph *callGraph
+
+	// cfgCache maps the function bodies to their lazily built CFGs.
+	cfgCache map[*ast.BlockStmt]*funcCFG
+
+	// packageMatches is set only for the PackageWhere() filters.
+	packageMatches *packageMatchGroup
+
 	// varname is set only for custom filters before bytecode function is called.
 	varname string
 
@@ -113,6 +157,85 @@ func (params *filterParams) typeofNode(n ast.Node) types.Type {
 	return invalidType
 }
 
+// constValueOf returns a constant value of e.
+// For non-constant expressions, it returns an unknown value.
+func (params *filterParams) constValueOf(e ast.Expr) constant.Value {
+	if e == nil {
+		return unknownValue
+	}
+	if tv, ok := params.ctx.Types.Types[e]; ok && tv.Value != nil {
+		return tv.Value
+	}
+	return unknownValue
+}
+
+// walkAncestors calls fn for every ancestor of the n node, starting from the closest one.
+// The child argument is an ancestor child node that leads to n (it can be n itself).
+// The n node should be a part of the current match.
+// Walking stops as soon as fn returns false.
+func (params *filterParams) walkAncestors(n ast.Node, fn func(n, child ast.Node) bool) {
+	if slice, ok := n.(*gogrep.NodeSlice); ok {
+		// All slice elements have the same ancestors.
+		if slice.Len() == 0 {
+			return
+		}
+		n = slice.At(0)
+	}
+
+	// The node path contains the ancestors of the visited node.
+	// For the node slice matches, the visited node is the slice container,
+	// so it's a first ancestor of the root node.
+	root := params.match.Node()
+	pathIndex := 0
+	var subtrees []ast.Node
+	if slice, ok := root.(*gogrep.NodeSlice); ok {
+		for i := 0; i < slice.Len(); i++ {
+			subtrees = append(subtrees, slice.At(i))
+		}
+	} else {
+		subtrees = append(subtrees, root)
+		pathIndex = 1
+	}
+
+	// Named submatches can be located deeper than the visited node,
+	// so we need to find a path to them from the root.
+	child := n
+	for _, subtree := range subtrees {
+		path, ok := findNodePath(subtree, n)
+		if !ok {
+			continue
+		}
+		for i := len(path) - 1; i >= 0; i-- {
+			if !fn(path[i], child) {
+				return
+			}
+			child = path[i]
+		}
+		break
+	}
+
+	for i := pathIndex; i < params.nodePath.Len(); i++ {
+		parent := params.nodePath.NthParent(i)
+		if !fn(parent, child) {
+			return
+		}
+		child = parent
+	}
+}
+
+// currentFuncSignature returns the enclosing function signature.
+// It returns nil if there is no enclosing function.
+func (params *filterParams) currentFuncSignature() *types.Signature {
+	if params.currentFunc == nil {
+		return nil
+	}
+	fn, ok := params.ctx.Types.ObjectOf(params.currentFunc.Name).(*types.Func)
+	if !ok {
+		return nil
+	}
+	return fn.Type().(*types.Signature)
+}
+
 func mergeRuleSets(toMerge []*goRuleSet) (*goRuleSet, error) {
 	out := &goRuleSet{
 		universal: &scopedGoRuleSet{},
@@ -121,6 +244,7 @@ func mergeRuleSets(toMerge []*goRuleSet) (*goRuleSet, error) {
 
 	for _, x := range toMerge {
 		out.universal = appendScopedRuleSet(out.universal, x.universal)
+		out.hasPackageRules = out.hasPackageRules || x.hasPackageRules
 		for groupName, group := range x.groups {
 			if prevGroup, ok := out.groups[groupName]; ok {
 				newRef := fmt.Sprintf("%s:%d", group.Filename, group.Line)
@@ -80,114 +80,168 @@ const (
 	// $Value type: string
 	FilterVarNodeIsOp FilterOp = 21
 
+	// m[$Value].Node.Ancestor($Args[0])
+	// $Value type: string
+	FilterVarNodeAncestorOp FilterOp = 22
+
+	// m[$Value].Node.InsideLoop()
+	// $Value type: string
+	FilterVarNodeInsideLoopOp FilterOp = 23
+
 	// m[$Value].Object.Is($Args[0])
 	// $Value type: string
-	FilterVarObjectIsOp FilterOp = 22
+	FilterVarObjectIsOp FilterOp = 24
 
 	// m[$Value].Object.IsGlobal()
 	// $Value type: string
-	FilterVarObjectIsGlobalOp FilterOp = 23
+	FilterVarObjectIsGlobalOp FilterOp = 25
 
 	// m[$Value].Object.IsVariadicParam()
 	// $Value type: string
-	FilterVarObjectIsVariadicParamOp FilterOp = 24
+	FilterVarObjectIsVariadicParamOp FilterOp = 26
+
+	// m[$Value].Object.DefinedBy($Args[0])
+	// $Value type: string
+	FilterVarObjectDefinedByOp FilterOp = 27
+
+	// m[$Value].Object.Calls($Args[0])
+	// $Value type: string
+	FilterVarObjectCallsOp FilterOp = 28
 
 	// m[$Value].Type.Is($Args[0])
 	// $Value type: string
-	FilterVarTypeIsOp FilterOp = 25
+	FilterVarTypeIsOp FilterOp = 29
 
 	// m[$Value].Type.IdenticalTo($Args[0])
 	// $Value type: string
-	FilterVarTypeIdenticalToOp FilterOp = 26
+	FilterVarTypeIdenticalToOp FilterOp = 30
 
 	// m[$Value].Type.Underlying().Is($Args[0])
 	// $Value type: string
-	FilterVarTypeUnderlyingIsOp FilterOp = 27
+	FilterVarTypeUnderlyingIsOp FilterOp = 31
 
 	// m[$Value].Type.OfKind($Args[0])
 	// $Value type: string
-	FilterVarTypeOfKindOp FilterOp = 28
+	FilterVarTypeOfKindOp FilterOp = 32
 
 	// m[$Value].Type.Underlying().OfKind($Args[0])
 	// $Value type: string
-	FilterVarTypeUnderlyingOfKindOp FilterOp = 29
+	FilterVarTypeUnderlyingOfKindOp FilterOp = 33
 
 	// m[$Value].Type.ConvertibleTo($Args[0])
 	// $Value type: string
-	FilterVarTypeConvertibleToOp FilterOp = 30
+	FilterVarTypeConvertibleToOp FilterOp = 34
 
 	// m[$Value].Type.AssignableTo($Args[0])
 	// $Value type: string
-	FilterVarTypeAssignableToOp FilterOp = 31
+	FilterVarTypeAssignableToOp FilterOp = 35
 
 	// m[$Value].Type.Implements($Args[0])
 	// $Value type: string
-	FilterVarTypeImplementsOp FilterOp = 32
+	FilterVarTypeImplementsOp FilterOp = 36
 
 	// m[$Value].Type.HasMethod($Args[0])
 	// $Value type: string
-	FilterVarTypeHasMethodOp FilterOp = 33
+	FilterVarTypeHasMethodOp FilterOp = 37
 
 	// m[$Value].Text.Matches($Args[0])
 	// $Value type: string
-	FilterVarTextMatchesOp FilterOp = 34
+	FilterVarTextMatchesOp FilterOp = 38
 
 	// m[$Value].Contains($Args[0])
 	// $Value type: string
-	FilterVarContainsOp FilterOp = 35
+	FilterVarContainsOp FilterOp = 39
+
+	// m[$Value].NotContains($Args[0])
+	// $Value type: string
+	FilterVarNotContainsOp FilterOp = 40
+
+	// m[$Value].FollowedBy($Args[0])
+	// $Value type: string
+	FilterVarFollowedByOp FilterOp = 41
+
+	// m[$Value].PrecededBy($Args[0])
+	// $Value type: string
+	FilterVarPrecededByOp FilterOp = 42
+
+	// m[$Value].AlwaysFollowedBy($Args[0])
+	// $Value type: string
+	FilterVarAlwaysFollowedByOp FilterOp = 43
 
 	// m.Deadcode()
-	FilterDeadcodeOp FilterOp = 36
+	FilterDeadcodeOp FilterOp = 44
 
 	// m.GoVersion().Eq($Value)
 	// $Value type: string
-	FilterGoVersionEqOp FilterOp = 37
+	FilterGoVersionEqOp FilterOp = 45
 
 	// m.GoVersion().LessThan($Value)
 	// $Value type: string
-	FilterGoVersionLessThanOp FilterOp = 38
+	FilterGoVersionLessThanOp FilterOp = 46
 
 	// m.GoVersion().GreaterThan($Value)
 	// $Value type: string
-	FilterGoVersionGreaterThanOp FilterOp = 39
+	FilterGoVersionGreaterThanOp FilterOp = 47
 
 	// m.GoVersion().LessEqThan($Value)
 	// $Value type: string
-	FilterGoVersionLessEqThanOp FilterOp = 40
+	FilterGoVersionLessEqThanOp FilterOp = 48
 
 	// m.GoVersion().GreaterEqThan($Value)
 	// $Value type: string
-	FilterGoVersionGreaterEqThanOp FilterOp = 41
+	FilterGoVersionGreaterEqThanOp FilterOp = 49
 
 	// m.File.Imports($Value)
 	// $Value type: string
-	FilterFileImportsOp FilterOp = 42
+	FilterFileImportsOp FilterOp = 50
 
 	// m.File.PkgPath.Matches($Value)
 	// $Value type: string
-	FilterFilePkgPathMatchesOp FilterOp = 43
+	FilterFilePkgPathMatchesOp FilterOp = 51
 
 	// m.File.Name.Matches($Value)
 	// $Value type: string
-	FilterFileNameMatchesOp FilterOp = 44
+	FilterFileNameMatchesOp FilterOp = 52
+
+	// m.Func().Name.Matches($Value)
+	// $Value type: string
+	FilterFuncNameMatchesOp FilterOp = 53
+
+	// m.Func().IsMethod
+	FilterFuncIsMethodOp FilterOp = 54
+
+	// m.Func().IsTest
+	FilterFuncIsTestOp FilterOp = 55
+
+	// m.Func().Receiver.Type.Is($Args[0])
+	FilterFuncReceiverTypeIsOp FilterOp = 56
+
+	// m.Func().Results.Contains($Args[0])
+	FilterFuncResultsContainsOp FilterOp = 57
+
+	// m.Matches().Count
+	FilterMatchesCountOp FilterOp = 58
+
+	// m.Matches().Files
+	FilterMatchesFilesOp FilterOp = 59
 
 	// $Value holds a function name
 	// $Value type: string
-	FilterFilterFuncRefOp FilterOp = 45
+	FilterFilterFuncRefOp FilterOp = 60
 
 	// $Value holds a string constant
 	// $Value type: string
-	FilterStringOp FilterOp = 46
+	FilterStringOp FilterOp = 61
 
 	// $Value holds an int64 constant
 	// $Value type: int64
-	FilterIntOp FilterOp = 47
+	FilterIntOp FilterOp = 62
 
 	// m[`$$`].Node.Parent().Is($Args[0])
-	FilterRootNodeParentIsOp FilterOp = 48
+	FilterRootNodeParentIsOp FilterOp = 63
 
 	// m[`$$`].SinkType.Is($Args[0])
-	FilterRootSinkTypeIsOp FilterOp = 49
+	FilterRootSinkTypeIsOp FilterOp = 64
 )
 
 var filterOpNames = map[FilterOp]string{
@@ -213,9 +267,13 @@ var filterOpNames = map[FilterOp]string{
 	FilterVarTypeHasPointersOp:       `VarTypeHasPointers`,
 	FilterVarFilterOp:                `VarFilter`,
 	FilterVarNodeIsOp:                `VarNodeIs`,
+	FilterVarNodeAncestorOp:          `VarNodeAncestor`,
+	FilterVarNodeInsideLoopOp:        `VarNodeInsideLoop`,
 	FilterVarObjectIsOp:              `VarObjectIs`,
 	FilterVarObjectIsGlobalOp:        `VarObjectIsGlobal`,
 	FilterVarObjectIsVariadicParamOp: `VarObjectIsVariadicParam`,
+	FilterVarObjectDefinedByOp:       `VarObjectDefinedBy`,
+	FilterVarObjectCallsOp:           `VarObjectCalls`,
 	FilterVarTypeIsOp:                `VarTypeIs`,
 	FilterVarTypeIdenticalToOp:       `VarTypeIdenticalTo`,
 	FilterVarTypeUnderlyingIsOp:      `VarTypeUnderlyingIs`,
@@ -227,6 +285,10 @@ var filterOpNames = map[FilterOp]string{
 	FilterVarTypeHasMethodOp:         `VarTypeHasMethod`,
 	FilterVarTextMatchesOp:           `VarTextMatches`,
 	FilterVarContainsOp:              `VarContains`,
+	FilterVarNotContainsOp:           `VarNotContains`,
+	FilterVarFollowedByOp:            `VarFollowedBy`,
+	FilterVarPrecededByOp:            `VarPrecededBy`,
+	FilterVarAlwaysFollowedByOp:      `VarAlwaysFollowedBy`,
 	FilterDeadcodeOp:                 `Deadcode`,
 	FilterGoVersionEqOp:              `GoVersionEq`,
 	FilterGoVersionLessThanOp:        `GoVersionLessThan`,
@@ -236,6 +298,13 @@ var filterOpNames = map[FilterOp]string{
 	FilterFileImportsOp:              `FileImports`,
 	FilterFilePkgPathMatchesOp:       `FilePkgPathMatches`,
 	FilterFileNameMatchesOp:          `FileNameMatches`,
+	FilterFuncNameMatchesOp:          `FuncNameMatches`,
+	FilterFuncIsMethodOp:             `FuncIsMethod`,
+	FilterFuncIsTestOp:               `FuncIsTest`,
+	FilterFuncReceiverTypeIsOp:       `FuncReceiverTypeIs`,
+	FilterFuncResultsContainsOp:      `FuncResultsContains`,
+	FilterMatchesCountOp:             `MatchesCount`,
+	FilterMatchesFilesOp:             `MatchesFiles`,
 	FilterFilterFuncRefOp:            `FilterFuncRef`,
 	FilterStringOp:                   `String`,
 	FilterIntOp:                      `Int`,
@@ -263,9 +332,13 @@ var filterOpFlags = map[FilterOp]uint64{
 	FilterVarTypeHasPointersOp:       flagHasVar,
 	FilterVarFilterOp:                flagHasVar,
 	FilterVarNodeIsOp:                flagHasVar,
+	FilterVarNodeAncestorOp:          flagHasVar,
+	FilterVarNodeInsideLoopOp:        flagHasVar,
 	FilterVarObjectIsOp:              flagHasVar,
 	FilterVarObjectIsGlobalOp:        flagHasVar,
 	FilterVarObjectIsVariadicParamOp: flagHasVar,
+	FilterVarObjectDefinedByOp:       flagHasVar,
+	FilterVarObjectCallsOp:           flagHasVar,
 	FilterVarTypeIsOp:                flagHasVar,
 	FilterVarTypeIdenticalToOp:       flagHasVar,
 	FilterVarTypeUnderlyingIsOp:      flagHasVar,
@@ -277,6 +350,10 @@ var filterOpFlags = map[FilterOp]uint64{
 	FilterVarTypeHasMethodOp:         flagHasVar,
 	FilterVarTextMatchesOp:           flagHasVar,
 	FilterVarContainsOp:              flagHasVar,
+	FilterVarNotContainsOp:           flagHasVar,
+	FilterVarFollowedByOp:            flagHasVar,
+	FilterVarPrecededByOp:            flagHasVar,
+	FilterVarAlwaysFollowedByOp:      flagHasVar,
 	FilterStringOp:                   flagIsBasicLit,
 	FilterIntOp:                      flagIsBasicLit,
 }
@@ -53,9 +53,13 @@ func main() {
 
 		{name: "VarFilter", comment: "m[$Value].Filter($Args[0])", valueType: "string", flags: flagHasVar},
 		{name: "VarNodeIs", comment: "m[$Value].Node.Is($Args[0])", valueType: "string", flags: flagHasVar},
+		{name: "VarNodeAncestor", comment: "m[$Value].Node.Ancestor($Args[0])", valueType: "string", flags: flagHasVar},
+		{name: "VarNodeInsideLoop", comment: "m[$Value].Node.InsideLoop()", valueType: "string", flags: flagHasVar},
 		{name: "VarObjectIs", comment: "m[$Value].Object.Is($Args[0])", valueType: "string", flags: flagHasVar},
 		{name: "VarObjectIsGlobal", comment: "m[$Value].Object.IsGlobal()", valueType: "string", flags: flagHasVar},
 		{name: "VarObjectIsVariadicParam", comment: "m[$Value].Object.IsVariadicParam()", valueType: "string", flags: flagHasVar},
+		{name: "VarObjectDefinedBy", comment: "m[$Value].Object.DefinedBy($Args[0])", valueType: "string", flags: flagHasVar},
+		{name: "VarObjectCalls", comment: "m[$Value].Object.Calls($Args[0])", valueType: "string", flags: flagHasVar},
 		{name: "VarTypeIs", comment: "m[$Value].Type.Is($Args[0])", valueType: "string", flags: flagHasVar},
 		{name: "VarTypeIdenticalTo", comment: "m[$Value].Type.IdenticalTo($Args[0])", valueType: "string", flags: flagHasVar},
 		{name: "VarTypeUnderlyingIs", comment: "m[$Value].Type.Underlying().Is($Args[0])", valueType: "string", flags: flagHasVar},
@@ -68,6 +72,10 @@ func main() {
 		{name: "VarTextMatches", comment: "m[$Value].Text.Matches($Args[0])", valueType: "string", flags: flagHasVar},
 
 		{name: "VarContains", comment: "m[$Value].Contains($Args[0])", valueType: "string", flags: flagHasVar},
+		{name: "VarNotContains", comment: "m[$Value].NotContains($Args[0])", valueType: "string", flags: flagHasVar},
+		{name: "VarFollowedBy", comment: "m[$Value].FollowedBy($Args[0])", valueType: "string", flags: flagHasVar},
+		{name: "VarPrecededBy", comment: "m[$Value].PrecededBy($Args[0])", valueType: "string", flags: flagHasVar},
+		{name: "VarAlwaysFollowedBy", comment: "m[$Value].AlwaysFollowedBy($Args[0])", valueType: "string", flags: flagHasVar},
 
 		{name: "Deadcode", comment: "m.Deadcode()"},
 
@@ -81,6 +89,15 @@ func main() {
 		{name: "FilePkgPathMatches", comment: "m.File.PkgPath.Matches($Value)", valueType: "string"},
 		{name: "FileNameMatches", comment: "m.File.Name.Matches($Value)", valueType: "string"},
 
+		{name: "FuncNameMatches", comment: "m.Func().Name.Matches($Value)", valueType: "string"},
+		{name: "FuncIsMethod", comment: "m.Func().IsMethod"},
+		{name: "FuncIsTest", comment: "m.Func().IsTest"},
+		{name: "FuncReceiverTypeIs", comment: "m.Func().Receiver.Type.Is($Args[0])"},
+		{name: "FuncResultsContains", comment: "m.Func().Results.Contains($Args[0])"},
+
+		{name: "MatchesCount", comment: "m.Matches().Count"},
+		{name: "MatchesFiles", comment: "m.Matches().Files"},
+
 		{name: "FilterFuncRef", comment: "$Value holds a function name", valueType: "string"},
 
 		{name: "String", comment: "$Value holds a string constant", valueType: "string", flags: flagIsBasicLit},
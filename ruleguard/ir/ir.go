@@ -33,6 +33,10 @@ type RuleGroup struct {
 	DocAfter   string
 	DocNote    string
 
+	// Severity is one of "error", "warning" or "info".
+	// An empty string means that severity was not specified.
+	Severity string
+
 	Imports []PackageImport
 
 	Rules []Rule
@@ -49,13 +53,43 @@ type Rule struct {
 	SyntaxPatterns  []PatternString
 	CommentPatterns []PatternString
 
-	ReportTemplate  string
-	SuggestTemplate string
-	DoFuncName      string
+	ReportTemplate string
+	DoFuncName     string
+
+	Suggestions []Suggestion
 
 	WhereExpr FilterExpr
 
+	// PackageWhereExpr is set for the package-scoped rules.
+	// It's evaluated once per PackageGroupBy matches group,
+	// after all package files are processed.
+	PackageWhereExpr FilterExpr
+	PackageGroupBy   []string
+
+	LocationVar string
+}
+
+// Suggestion is a quickfix template with an optional label.
+// Every suggestion is an independent fix for a reported issue.
+type Suggestion struct {
+	Line int
+
+	Label string
+
+	// Template replaces the reported node.
+	// It's empty if this suggestion consists of SuggestAt() edits only.
+	Template string
+
+	// Edits are applied together with the Template replacement.
+	Edits []SuggestionEdit
+}
+
+// SuggestionEdit is a SuggestAt() replacement of the LocationVar node.
+type SuggestionEdit struct {
+	Line int
+
 	LocationVar string
+	Template    string
 }
 
 type PatternString struct {
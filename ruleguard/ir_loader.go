@@ -225,6 +225,10 @@ func (l *irLoader) compileFilterFuncs(filename string, irfile *ir.File) error {
 }
 
 func (l *irLoader) loadRuleGroup(group *ir.RuleGroup) error {
+	severity, err := ParseSeverity(group.Severity)
+	if err != nil {
+		return l.errorf(group.Line, err, "parse severity")
+	}
 	l.group = &GoRuleGroup{
 		Line:       group.Line,
 		Filename:   l.filename,
@@ -234,6 +238,7 @@ func (l *irLoader) loadRuleGroup(group *ir.RuleGroup) error {
 		DocAfter:   group.DocAfter,
 		DocNote:    group.DocNote,
 		DocTags:    group.DocTags,
+		Severity:   severity,
 	}
 	if l.prefix != "" {
 		l.group.Name = l.prefix + "/" + l.group.Name
@@ -266,11 +271,14 @@ func (l *irLoader) loadRuleGroup(group *ir.RuleGroup) error {
 
 func (l *irLoader) loadRule(group *ir.RuleGroup, rule *ir.Rule) error {
 	proto := goRule{
-		line:       rule.Line,
-		group:      l.group,
-		suggestion: rule.SuggestTemplate,
-		msg:        rule.ReportTemplate,
-		location:   rule.LocationVar,
+		line:     rule.Line,
+		group:    l.group,
+		msg:      rule.ReportTemplate,
+		location: rule.LocationVar,
+	}
+
+	for _, s := range rule.Suggestions {
+		proto.suggestions = append(proto.suggestions, l.loadSuggestion(s))
 	}
 
 	if rule.DoFuncName != "" {
@@ -293,6 +301,15 @@ func (l *irLoader) loadRule(group *ir.RuleGroup, rule *ir.Rule) error {
 		proto.filter = filter
 	}
 
+	if rule.PackageWhereExpr.IsValid() {
+		scope, err := l.loadPackageScope(group, rule)
+		if err != nil {
+			return err
+		}
+		proto.pkgScope = scope
+		l.res.hasPackageRules = true
+	}
+
 	for _, pat := range rule.SyntaxPatterns {
 		if err := l.loadSyntaxRule(group, proto, info, rule, pat.Value, pat.Line); err != nil {
 			return err
@@ -306,6 +323,52 @@ func (l *irLoader) loadRule(group *ir.RuleGroup, rule *ir.Rule) error {
 	return nil
 }
 
+func (l *irLoader) loadPackageScope(group *ir.RuleGroup, rule *ir.Rule) (*packageScope, error) {
+	if !isPackageFilterExpr(rule.PackageWhereExpr) {
+		return nil, l.errorf(rule.Line, nil, "only m.Matches() conditions can be used inside PackageWhere()")
+	}
+	info := filterInfo{
+		Vars:          make(map[string]struct{}),
+		group:         group,
+		packageScoped: true,
+	}
+	filter, err := l.newFilter(rule.PackageWhereExpr, &info)
+	if err != nil {
+		return nil, err
+	}
+	return &packageScope{filter: filter, groupBy: rule.PackageGroupBy}, nil
+}
+
+func (l *irLoader) loadSuggestion(s ir.Suggestion) goRuleSuggestion {
+	result := goRuleSuggestion{
+		label:    s.Label,
+		template: s.Template,
+	}
+	templates := []string{s.Template}
+	for _, e := range s.Edits {
+		result.edits = append(result.edits, goRuleSuggestionEdit{
+			location: e.LocationVar,
+			template: e.Template,
+		})
+		templates = append(templates, e.Template)
+	}
+
+	for _, tmpl := range templates {
+		for _, pkgName := range templatePackageRefs(tmpl) {
+			pkgPath, ok := l.itab.Lookup(pkgName)
+			if !ok {
+				continue
+			}
+			imported := goRuleSuggestionImport{name: pkgName, path: pkgPath}
+			if !containsSuggestionImport(result.imports, imported) {
+				result.imports = append(result.imports, imported)
+			}
+		}
+	}
+
+	return result
+}
+
 func (l *irLoader) loadCommentRule(resultProto goRule, rule *ir.Rule, src string, line int) error {
 	dst := l.res.universal
 	pat, err := regexp.Compile(src)
@@ -362,6 +425,21 @@ func (l *irLoader) loadSyntaxRule(group *ir.RuleGroup, resultProto goRule, filte
 			return l.errorf(rule.Line, nil, "filter refers to a non-existing var %s", filterVar)
 		}
 	}
+	for _, v := range rule.PackageGroupBy {
+		if _, ok := info.Vars[v]; !ok && v != "$$" {
+			return l.errorf(rule.Line, nil, "PackageWhere() refers to a non-existing var %s", v)
+		}
+	}
+	for _, s := range rule.Suggestions {
+		for _, e := range s.Edits {
+			if e.LocationVar == "$$" {
+				continue
+			}
+			if _, ok := info.Vars[e.LocationVar]; !ok {
+				return l.errorf(e.Line, nil, "suggestion refers to a non-existing var %s", e.LocationVar)
+			}
+		}
+	}
 
 	dst := l.res.universal
 	var dstTags []nodetag.Value
@@ -594,6 +672,13 @@ func (l *irLoader) newFilter(filter ir.FilterExpr, info *filterInfo) (matchFilte
 		}
 		result.fn = makeObjectIsFilter(result.src, filter.Value.(string), typeString)
 
+	case ir.FilterVarObjectCallsOp:
+		target := l.unwrapStringExpr(filter.Args[0])
+		if target == "" {
+			return result, l.errorf(filter.Line, nil, "expected a non-empty string argument")
+		}
+		result.fn = makeObjectCallsFilter(result.src, filter.Value.(string), target)
+
 	case ir.FilterRootNodeParentIsOp:
 		tag, err := l.unwrapNodeTagExpr(filter.Args[0])
 		if err != nil {
@@ -608,6 +693,16 @@ func (l *irLoader) newFilter(filter ir.FilterExpr, info *filterInfo) (matchFilte
 		}
 		result.fn = makeNodeIsFilter(result.src, filter.Value.(string), tag)
 
+	case ir.FilterVarNodeAncestorOp:
+		tag, err := l.unwrapNodeTagExpr(filter.Args[0])
+		if err != nil {
+			return result, err
+		}
+		result.fn = makeNodeAncestorFilter(result.src, filter.Value.(string), tag)
+
+	case ir.FilterVarNodeInsideLoopOp:
+		result.fn = makeNodeInsideLoopFilter(result.src, filter.Value.(string))
+
 	case ir.FilterRootSinkTypeIsOp:
 		typeString := l.unwrapStringExpr(filter.Args[0])
 		if typeString == "" {
@@ -759,6 +854,35 @@ func (l *irLoader) newFilter(filter ir.FilterExpr, info *filterInfo) (matchFilte
 		}
 		result.fn = makeFileNameMatchesFilter(result.src, re)
 
+	case ir.FilterFuncNameMatchesOp:
+		re, err := regexp.Compile(filter.Value.(string))
+		if err != nil {
+			return result, l.errorf(filter.Line, err, "compile regexp")
+		}
+		result.fn = makeFuncNameMatchesFilter(result.src, re)
+
+	case ir.FilterFuncIsMethodOp:
+		result.fn = makeFuncIsMethodFilter(result.src)
+
+	case ir.FilterFuncIsTestOp:
+		result.fn = makeFuncIsTestFilter(result.src)
+
+	case ir.FilterFuncReceiverTypeIsOp, ir.FilterFuncResultsContainsOp:
+		typeString := l.unwrapStringExpr(filter.Args[0])
+		if typeString == "" {
+			return result, l.errorf(filter.Line, nil, "expected a non-empty string argument")
+		}
+		ctx := typematch.Context{Itab: l.itab}
+		pat, err := typematch.Parse(&ctx, typeString)
+		if err != nil {
+			return result, l.errorf(filter.Line, err, "parse type expr")
+		}
+		if filter.Op == ir.FilterFuncReceiverTypeIsOp {
+			result.fn = makeFuncReceiverTypeIsFilter(result.src, pat)
+		} else {
+			result.fn = makeFuncResultsContainsFilter(result.src, pat)
+		}
+
 	case ir.FilterVarContainsOp:
 		src := filter.Args[0].Value.(string)
 		pat, _, err := l.gogrepCompile(info.group, src)
@@ -767,6 +891,39 @@ func (l *irLoader) newFilter(filter ir.FilterExpr, info *filterInfo) (matchFilte
 		}
 		result.fn = makeVarContainsFilter(result.src, filter.Value.(string), pat)
 
+	case ir.FilterVarNotContainsOp:
+		src := filter.Args[0].Value.(string)
+		pat, _, err := l.gogrepCompile(info.group, src)
+		if err != nil {
+			return result, l.errorf(filter.Line, err, "parse not contains pattern")
+		}
+		result.fn = makeVarNotContainsFilter(result.src, filter.Value.(string), pat)
+
+	case ir.FilterVarObjectDefinedByOp:
+		src := filter.Args[0].Value.(string)
+		pat, _, err := l.gogrepCompile(info.group, src)
+		if err != nil {
+			return result, l.errorf(filter.Line, err, "parse defined by pattern")
+		}
+		result.fn = makeObjectDefinedByFilter(result.src, filter.Value.(string), pat)
+
+	case ir.FilterVarFollowedByOp, ir.FilterVarPrecededByOp:
+		src := filter.Args[0].Value.(string)
+		pat, _, err := l.gogrepCompile(info.group, src)
+		if err != nil {
+			return result, l.errorf(filter.Line, err, "parse sibling stmt pattern")
+		}
+		followed := filter.Op == ir.FilterVarFollowedByOp
+		result.fn = makeVarSiblingStmtFilter(result.src, filter.Value.(string), followed, pat)
+
+	case ir.FilterVarAlwaysFollowedByOp:
+		src := filter.Args[0].Value.(string)
+		pat, _, err := l.gogrepCompile(info.group, src)
+		if err != nil {
+			return result, l.errorf(filter.Line, err, "parse always followed by pattern")
+		}
+		result.fn = makeVarAlwaysFollowedByFilter(result.src, filter.Value.(string), pat)
+
 	case ir.FilterVarFilterOp:
 		funcName := filter.Args[0].Value.(string)
 		userFn := l.state.env.GetFunc(l.file.PkgPath, funcName)
@@ -874,6 +1031,18 @@ func (l *irLoader) newBinaryExprFilter(filter ir.FilterExpr, info *filterInfo) (
 		} else if rhs.Op == lhs.Op {
 			result.fn = makeTextFilter(result.src, lhs.Value.(string), tok, rhs.Value.(string))
 		}
+	case ir.FilterMatchesCountOp, ir.FilterMatchesFilesOp:
+		if !info.packageScoped {
+			return result, l.errorf(filter.Line, nil, "m.Matches() can only be used inside PackageWhere()")
+		}
+		if rhsValue == nil {
+			break
+		}
+		if lhs.Op == ir.FilterMatchesCountOp {
+			result.fn = makeMatchesCountConstFilter(result.src, tok, rhsValue)
+		} else {
+			result.fn = makeMatchesFilesConstFilter(result.src, tok, rhsValue)
+		}
 	}
 
 	if result.fn == nil {
@@ -883,8 +1052,30 @@ func (l *irLoader) newBinaryExprFilter(filter ir.FilterExpr, info *filterInfo) (
 	return result, nil
 }
 
+// isPackageFilterExpr reports whether e can be evaluated for
+// a package matches group, without any particular match.
+func isPackageFilterExpr(e ir.FilterExpr) bool {
+	switch e.Op {
+	case ir.FilterMatchesCountOp, ir.FilterMatchesFilesOp, ir.FilterIntOp:
+		return true
+	case ir.FilterNotOp, ir.FilterAndOp, ir.FilterOrOp,
+		ir.FilterEqOp, ir.FilterNeqOp, ir.FilterGtOp, ir.FilterLtOp, ir.FilterGtEqOp, ir.FilterLtEqOp:
+		for _, arg := range e.Args {
+			if !isPackageFilterExpr(arg) {
+				return false
+			}
+		}
+		return true
+	default:
+		return false
+	}
+}
+
 type filterInfo struct {
 	Vars map[string]struct{}
 
+	// packageScoped is true for the PackageWhere() conditions.
+	packageScoped bool
+
 	group *ir.RuleGroup
 }
@@ -98,7 +98,8 @@ func (conv *converter) ConvertFile(f *ast.File) *ir.File {
 		// Right now this list is hardcoded from the knowledge of which
 		// stdlib packages are supported inside the bytecode.
 		switch importPath {
-		case "fmt", "strings", "strconv":
+		case "fmt", "strings", "strconv", "unicode", "regexp", "path", "path/filepath", "sort",
+			"github.com/quasilyte/go-ruleguard/dsl/ast", "github.com/quasilyte/go-ruleguard/dsl/constant":
 			conv.addCustomImport(result, importPath)
 		}
 	}
@@ -401,6 +402,7 @@ func (conv *converter) convertDocComments(comment *ast.CommentGroup) {
 		"before",
 		"after",
 		"note",
+		"severity",
 	}
 
 	for _, c := range comment.List {
@@ -431,6 +433,13 @@ func (conv *converter) convertDocComments(comment *ast.CommentGroup) {
 			conv.group.DocNote = s
 		case "tags":
 			conv.group.DocTags = strings.Fields(s)
+		case "severity":
+			switch s {
+			case "error", "warning", "info":
+				conv.group.Severity = s
+			default:
+				panic(conv.errorf(c, "unexpected severity %q, expected error, warning or info", s))
+			}
 		default:
 			panic("unhandled 'doc' pragma: " + pragma) // Should never happen
 		}
@@ -443,7 +452,8 @@ func (conv *converter) convertRuleExpr(call *ast.CallExpr) {
 		matchArgs        *[]ast.Expr
 		matchCommentArgs *[]ast.Expr
 		whereArgs        *[]ast.Expr
-		suggestArgs      *[]ast.Expr
+		packageWhereArgs *[]ast.Expr
+		suggestCalls     []*ast.CallExpr
 		reportArgs       *[]ast.Expr
 		atArgs           *[]ast.Expr
 		doArgs           *[]ast.Expr
@@ -476,11 +486,15 @@ func (conv *converter) convertRuleExpr(call *ast.CallExpr) {
 				panic(conv.errorf(chain.Sel, "Where() can't be repeated"))
 			}
 			whereArgs = &call.Args
-		case "Suggest":
-			if suggestArgs != nil {
-				panic(conv.errorf(chain.Sel, "Suggest() can't be repeated"))
+		case "PackageWhere":
+			if packageWhereArgs != nil {
+				panic(conv.errorf(chain.Sel, "PackageWhere() can't be repeated"))
 			}
-			suggestArgs = &call.Args
+			packageWhereArgs = &call.Args
+		case "Suggest", "SuggestLabeled", "SuggestAt":
+			// We're walking the chain from the end, so prepend
+			// the calls to preserve the source code order.
+			suggestCalls = append([]*ast.CallExpr{call}, suggestCalls...)
 		case "Report":
 			if reportArgs != nil {
 				panic(conv.errorf(chain.Sel, "Report() can't be repeated"))
@@ -536,15 +550,26 @@ func (conv *converter) convertRuleExpr(call *ast.CallExpr) {
 		rule.WhereExpr = conv.convertFilterExpr((*whereArgs)[0])
 	}
 
-	if suggestArgs != nil {
-		rule.SuggestTemplate = conv.parseStringArg((*suggestArgs)[0])
+	if packageWhereArgs != nil {
+		rule.PackageWhereExpr = conv.convertFilterExpr((*packageWhereArgs)[0])
+		for _, arg := range (*packageWhereArgs)[1:] {
+			index, ok := arg.(*ast.IndexExpr)
+			if !ok {
+				panic(conv.errorf(arg, "expected %s[`varname`] expression", conv.group.MatcherName))
+			}
+			rule.PackageGroupBy = append(rule.PackageGroupBy, conv.parseStringArg(index.Index))
+		}
 	}
 
-	if suggestArgs == nil && reportArgs == nil && doArgs == nil {
+	for _, call := range suggestCalls {
+		conv.convertSuggestCall(&rule, call)
+	}
+
+	if suggestCalls == nil && reportArgs == nil && doArgs == nil {
 		panic(conv.errorf(origCall, "missing Report(), Suggest() or Do() call"))
 	}
 	if doArgs != nil {
-		if suggestArgs != nil || reportArgs != nil {
+		if suggestCalls != nil || reportArgs != nil {
 			panic(conv.errorf(origCall, "can't combine Report/Suggest with Do yet"))
 		}
 		if matchCommentArgs != nil {
@@ -557,7 +582,10 @@ func (conv *converter) convertRuleExpr(call *ast.CallExpr) {
 		rule.DoFuncName = funcName.String()
 	} else {
 		if reportArgs == nil {
-			rule.ReportTemplate = "suggestion: " + rule.SuggestTemplate
+			if rule.Suggestions[0].Template == "" {
+				panic(conv.errorf(origCall, "missing Report() call; it can't be inferred from SuggestAt()"))
+			}
+			rule.ReportTemplate = "suggestion: " + rule.Suggestions[0].Template
 		} else {
 			rule.ReportTemplate = conv.parseStringArg((*reportArgs)[0])
 		}
@@ -577,6 +605,43 @@ func (conv *converter) convertRuleExpr(call *ast.CallExpr) {
 	conv.group.Rules = append(conv.group.Rules, rule)
 }
 
+func (conv *converter) convertSuggestCall(rule *ir.Rule, call *ast.CallExpr) {
+	line := conv.fset.Position(call.Pos()).Line
+	switch call.Fun.(*ast.SelectorExpr).Sel.Name {
+	case "Suggest":
+		rule.Suggestions = append(rule.Suggestions, ir.Suggestion{
+			Line:     line,
+			Template: conv.parseStringArg(call.Args[0]),
+		})
+	case "SuggestLabeled":
+		label := conv.parseStringArg(call.Args[0])
+		if label == "" {
+			panic(conv.errorf(call.Args[0], "empty suggestion label"))
+		}
+		rule.Suggestions = append(rule.Suggestions, ir.Suggestion{
+			Line:     line,
+			Label:    label,
+			Template: conv.parseStringArg(call.Args[1]),
+		})
+	case "SuggestAt":
+		index, ok := call.Args[0].(*ast.IndexExpr)
+		if !ok {
+			panic(conv.errorf(call.Args[0], "expected %s[`varname`] expression", conv.group.MatcherName))
+		}
+		// SuggestAt() extends the last suggestion.
+		// If there is none, it starts a new one.
+		if len(rule.Suggestions) == 0 {
+			rule.Suggestions = append(rule.Suggestions, ir.Suggestion{Line: line})
+		}
+		s := &rule.Suggestions[len(rule.Suggestions)-1]
+		s.Edits = append(s.Edits, ir.SuggestionEdit{
+			Line:        line,
+			LocationVar: conv.parseStringArg(index.Index),
+			Template:    conv.parseStringArg(call.Args[1]),
+		})
+	}
+}
+
 func (conv *converter) convertFilterExpr(e ast.Expr) ir.FilterExpr {
 	result := conv.convertFilterExprImpl(e)
 	result.Src = goutil.SprintNode(conv.fset, e)
@@ -666,6 +731,14 @@ func (conv *converter) convertFilterExprImpl(e ast.Expr) ir.FilterExpr {
 			return ir.FilterExpr{Op: ir.FilterVarComparableOp, Value: op.varName}
 		case "Type.Size":
 			return ir.FilterExpr{Op: ir.FilterVarTypeSizeOp, Value: op.varName}
+		case "Func.IsMethod":
+			return ir.FilterExpr{Op: ir.FilterFuncIsMethodOp}
+		case "Func.IsTest":
+			return ir.FilterExpr{Op: ir.FilterFuncIsTestOp}
+		case "Matches.Count":
+			return ir.FilterExpr{Op: ir.FilterMatchesCountOp}
+		case "Matches.Files":
+			return ir.FilterExpr{Op: ir.FilterMatchesFilesOp}
 		}
 
 	case *ast.CallExpr:
@@ -689,11 +762,26 @@ func (conv *converter) convertFilterExprImpl(e ast.Expr) ir.FilterExpr {
 			return ir.FilterExpr{Op: ir.FilterFilePkgPathMatchesOp, Value: conv.parseStringArg(e.Args[0])}
 		case "File.Name.Matches":
 			return ir.FilterExpr{Op: ir.FilterFileNameMatchesOp, Value: conv.parseStringArg(e.Args[0])}
+		case "Func.Name.Matches":
+			return ir.FilterExpr{Op: ir.FilterFuncNameMatchesOp, Value: conv.parseStringArg(e.Args[0])}
 
-		case "Contains":
+		case "Contains", "NotContains", "FollowedBy", "PrecededBy", "AlwaysFollowedBy":
 			pat := conv.parseStringArg(e.Args[0])
+			var filterOp ir.FilterOp
+			switch op.path {
+			case "Contains":
+				filterOp = ir.FilterVarContainsOp
+			case "NotContains":
+				filterOp = ir.FilterVarNotContainsOp
+			case "FollowedBy":
+				filterOp = ir.FilterVarFollowedByOp
+			case "PrecededBy":
+				filterOp = ir.FilterVarPrecededByOp
+			case "AlwaysFollowedBy":
+				filterOp = ir.FilterVarAlwaysFollowedByOp
+			}
 			return ir.FilterExpr{
-				Op:    ir.FilterVarContainsOp,
+				Op:    filterOp,
 				Value: op.varName,
 				Args: []ir.FilterExpr{
 					{Op: ir.FilterStringOp, Value: pat},
@@ -735,6 +823,10 @@ func (conv *converter) convertFilterExprImpl(e ast.Expr) ir.FilterExpr {
 			return ir.FilterExpr{Op: ir.FilterVarTextMatchesOp, Value: op.varName, Args: args}
 		case "Node.Is":
 			return ir.FilterExpr{Op: ir.FilterVarNodeIsOp, Value: op.varName, Args: args}
+		case "Node.Ancestor":
+			return ir.FilterExpr{Op: ir.FilterVarNodeAncestorOp, Value: op.varName, Args: args}
+		case "Node.InsideLoop":
+			return ir.FilterExpr{Op: ir.FilterVarNodeInsideLoopOp, Value: op.varName}
 		case "Node.Parent.Is":
 			if op.varName != "$$" {
 				// TODO: remove this restriction.
@@ -747,6 +839,10 @@ func (conv *converter) convertFilterExprImpl(e ast.Expr) ir.FilterExpr {
 			return ir.FilterExpr{Op: ir.FilterVarObjectIsGlobalOp, Value: op.varName}
 		case "Object.IsVariadicParam":
 			return ir.FilterExpr{Op: ir.FilterVarObjectIsVariadicParamOp, Value: op.varName}
+		case "Object.DefinedBy":
+			return ir.FilterExpr{Op: ir.FilterVarObjectDefinedByOp, Value: op.varName, Args: args}
+		case "Object.Calls":
+			return ir.FilterExpr{Op: ir.FilterVarObjectCallsOp, Value: op.varName, Args: args}
 		case "SinkType.Is":
 			if op.varName != "$$" {
 				// TODO: remove this restriction.
@@ -771,6 +867,10 @@ func (conv *converter) convertFilterExprImpl(e ast.Expr) ir.FilterExpr {
 			return ir.FilterExpr{Op: ir.FilterVarTypeImplementsOp, Value: op.varName, Args: args}
 		case "Type.HasMethod":
 			return ir.FilterExpr{Op: ir.FilterVarTypeHasMethodOp, Value: op.varName, Args: args}
+		case "Func.Receiver.Type.Is":
+			return ir.FilterExpr{Op: ir.FilterFuncReceiverTypeIsOp, Args: args}
+		case "Func.Results.Contains":
+			return ir.FilterExpr{Op: ir.FilterFuncResultsContainsOp, Args: args}
 		}
 	}
 
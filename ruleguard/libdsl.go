@@ -2,10 +2,14 @@ package ruleguard
 
 import (
 	"fmt"
+	"go/ast"
+	"go/constant"
 	"go/types"
+	"reflect"
 
 	"github.com/quasilyte/go-ruleguard/internal/xtypes"
 	"github.com/quasilyte/go-ruleguard/ruleguard/quasigo"
+	"golang.org/x/tools/go/ast/astutil"
 )
 
 // This file implements `dsl/*` packages as native functions in quasigo.
@@ -39,7 +43,22 @@ func initEnv(state *engineState, env *quasigo.Env) {
 		`*github.com/quasilyte/go-ruleguard/dsl/types.Struct`:     dslTypesStruct{},
 		`*github.com/quasilyte/go-ruleguard/dsl/types.Array`:      dslTypesArray{},
 		`*github.com/quasilyte/go-ruleguard/dsl/types.Slice`:      dslTypesSlice{},
+		`*github.com/quasilyte/go-ruleguard/dsl/types.Named`:      dslTypesNamed{},
+		`*github.com/quasilyte/go-ruleguard/dsl/types.TypeParam`:  dslTypesTypeParam{},
 		`*github.com/quasilyte/go-ruleguard/dsl/types.Var`:        dslTypesVar{},
+
+		`*github.com/quasilyte/go-ruleguard/dsl/types.TypeParamList`: dslTypesTypeParamList{},
+		`*github.com/quasilyte/go-ruleguard/dsl/types.Func`:          dslTypesFunc{},
+		`*github.com/quasilyte/go-ruleguard/dsl/types.TypeName`:      dslTypesTypeName{},
+		`*github.com/quasilyte/go-ruleguard/dsl/types.Package`:       dslTypesPackageObject{},
+
+		`*github.com/quasilyte/go-ruleguard/dsl/ast.Ident`:        dslAstIdent{},
+		`*github.com/quasilyte/go-ruleguard/dsl/ast.BasicLit`:     dslAstBasicLit{},
+		`*github.com/quasilyte/go-ruleguard/dsl/ast.CallExpr`:     dslAstCallExpr{},
+		`*github.com/quasilyte/go-ruleguard/dsl/ast.SelectorExpr`: dslAstSelectorExpr{},
+		`*github.com/quasilyte/go-ruleguard/dsl/ast.CompositeLit`: dslAstCompositeLit{},
+		`*github.com/quasilyte/go-ruleguard/dsl/ast.FuncLit`:      dslAstFuncLit{},
+		`github.com/quasilyte/go-ruleguard/dsl/constant.Value`:    dslConstantValue{},
 	}
 
 	for qualifier, typ := range nativeTypes {
@@ -49,7 +68,9 @@ func initEnv(state *engineState, env *quasigo.Env) {
 	}
 
 	nativePackages := map[string]quasigoNative{
-		`github.com/quasilyte/go-ruleguard/dsl/types`: dslTypesPackage{},
+		`github.com/quasilyte/go-ruleguard/dsl/types`:    dslTypesPackage{},
+		`github.com/quasilyte/go-ruleguard/dsl/ast`:      dslAstPackage{},
+		`github.com/quasilyte/go-ruleguard/dsl/constant`: dslConstantPackage{},
 	}
 
 	for qualifier, pkg := range nativePackages {
@@ -176,6 +197,7 @@ func (native dslTypesStruct) funcs() map[string]func(*quasigo.ValueStack) {
 		"String":     native.String,
 		"NumFields":  native.NumFields,
 		"Field":      native.Field,
+		"Tag":        native.Tag,
 	}
 }
 
@@ -197,6 +219,107 @@ func (dslTypesStruct) Field(stack *quasigo.ValueStack) {
 	stack.Push(typ.Field(i))
 }
 
+func (dslTypesStruct) Tag(stack *quasigo.ValueStack) {
+	i := stack.PopInt()
+	typ := stack.Pop().(*types.Struct)
+	stack.Push(typ.Tag(i))
+}
+
+type dslTypesNamed struct{}
+
+func (native dslTypesNamed) funcs() map[string]func(*quasigo.ValueStack) {
+	return map[string]func(*quasigo.ValueStack){
+		"Underlying": native.Underlying,
+		"String":     native.String,
+		"Obj":        native.Obj,
+		"NumMethods": native.NumMethods,
+		"Method":     native.Method,
+		"TypeParams": native.TypeParams,
+	}
+}
+
+func (dslTypesNamed) Underlying(stack *quasigo.ValueStack) {
+	stack.Push(stack.Pop().(*types.Named).Underlying())
+}
+
+func (dslTypesNamed) String(stack *quasigo.ValueStack) {
+	stack.Push(stack.Pop().(*types.Named).String())
+}
+
+func (dslTypesNamed) Obj(stack *quasigo.ValueStack) {
+	stack.Push(stack.Pop().(*types.Named).Obj())
+}
+
+func (dslTypesNamed) NumMethods(stack *quasigo.ValueStack) {
+	stack.PushInt(stack.Pop().(*types.Named).NumMethods())
+}
+
+func (dslTypesNamed) Method(stack *quasigo.ValueStack) {
+	i := stack.PopInt()
+	typ := stack.Pop().(*types.Named)
+	stack.Push(typ.Method(i))
+}
+
+func (dslTypesNamed) TypeParams(stack *quasigo.ValueStack) {
+	list := stack.Pop().(*types.Named).TypeParams()
+	if list == nil {
+		// A nil list is valid, but we promise a non-nil result.
+		list = &types.TypeParamList{}
+	}
+	stack.Push(list)
+}
+
+type dslTypesTypeParam struct{}
+
+func (native dslTypesTypeParam) funcs() map[string]func(*quasigo.ValueStack) {
+	return map[string]func(*quasigo.ValueStack){
+		"Underlying": native.Underlying,
+		"String":     native.String,
+		"Obj":        native.Obj,
+		"Index":      native.Index,
+		"Constraint": native.Constraint,
+	}
+}
+
+func (dslTypesTypeParam) Underlying(stack *quasigo.ValueStack) {
+	stack.Push(stack.Pop().(*types.TypeParam).Underlying())
+}
+
+func (dslTypesTypeParam) String(stack *quasigo.ValueStack) {
+	stack.Push(stack.Pop().(*types.TypeParam).String())
+}
+
+func (dslTypesTypeParam) Obj(stack *quasigo.ValueStack) {
+	stack.Push(stack.Pop().(*types.TypeParam).Obj())
+}
+
+func (dslTypesTypeParam) Index(stack *quasigo.ValueStack) {
+	stack.PushInt(stack.Pop().(*types.TypeParam).Index())
+}
+
+func (dslTypesTypeParam) Constraint(stack *quasigo.ValueStack) {
+	stack.Push(stack.Pop().(*types.TypeParam).Constraint())
+}
+
+type dslTypesTypeParamList struct{}
+
+func (native dslTypesTypeParamList) funcs() map[string]func(*quasigo.ValueStack) {
+	return map[string]func(*quasigo.ValueStack){
+		"Len": native.Len,
+		"At":  native.At,
+	}
+}
+
+func (dslTypesTypeParamList) Len(stack *quasigo.ValueStack) {
+	stack.PushInt(stack.Pop().(*types.TypeParamList).Len())
+}
+
+func (dslTypesTypeParamList) At(stack *quasigo.ValueStack) {
+	i := stack.PopInt()
+	list := stack.Pop().(*types.TypeParamList)
+	stack.Push(list.At(i))
+}
+
 type dslTypesPackage struct{}
 
 func (native dslTypesPackage) funcs() map[string]func(*quasigo.ValueStack) {
@@ -211,6 +334,10 @@ func (native dslTypesPackage) funcs() map[string]func(*quasigo.ValueStack) {
 		"AsPointer":   native.AsPointer,
 		"AsInterface": native.AsInterface,
 		"AsStruct":    native.AsStruct,
+
+		"AsNamed":      native.AsNamed,
+		"AsTypeParam":  native.AsTypeParam,
+		"StructTagGet": native.StructTagGet,
 	}
 }
 
@@ -267,15 +394,41 @@ func (dslTypesPackage) AsStruct(stack *quasigo.ValueStack) {
 	stack.Push(typ)
 }
 
+func (dslTypesPackage) AsNamed(stack *quasigo.ValueStack) {
+	typ, _ := stack.Pop().(types.Type).(*types.Named)
+	stack.Push(typ)
+}
+
+func (dslTypesPackage) AsTypeParam(stack *quasigo.ValueStack) {
+	typ, _ := stack.Pop().(types.Type).(*types.TypeParam)
+	stack.Push(typ)
+}
+
+func (dslTypesPackage) StructTagGet(stack *quasigo.ValueStack) {
+	key := stack.Pop().(string)
+	tag := stack.Pop().(string)
+	stack.Push(reflect.StructTag(tag).Get(key))
+}
+
 type dslTypesVar struct{}
 
 func (native dslTypesVar) funcs() map[string]func(*quasigo.ValueStack) {
 	return map[string]func(*quasigo.ValueStack){
+		"Name":     native.Name,
+		"Exported": native.Exported,
 		"Embedded": native.Embedded,
 		"Type":     native.Type,
 	}
 }
 
+func (dslTypesVar) Name(stack *quasigo.ValueStack) {
+	stack.Push(stack.Pop().(*types.Var).Name())
+}
+
+func (dslTypesVar) Exported(stack *quasigo.ValueStack) {
+	stack.Push(stack.Pop().(*types.Var).Exported())
+}
+
 func (dslTypesVar) Embedded(stack *quasigo.ValueStack) {
 	stack.Push(stack.Pop().(*types.Var).Embedded())
 }
@@ -284,6 +437,69 @@ func (dslTypesVar) Type(stack *quasigo.ValueStack) {
 	stack.Push(stack.Pop().(*types.Var).Type())
 }
 
+type dslTypesFunc struct{}
+
+func (native dslTypesFunc) funcs() map[string]func(*quasigo.ValueStack) {
+	return map[string]func(*quasigo.ValueStack){
+		"Name":     native.Name,
+		"Exported": native.Exported,
+		"Type":     native.Type,
+	}
+}
+
+func (dslTypesFunc) Name(stack *quasigo.ValueStack) {
+	stack.Push(stack.Pop().(*types.Func).Name())
+}
+
+func (dslTypesFunc) Exported(stack *quasigo.ValueStack) {
+	stack.Push(stack.Pop().(*types.Func).Exported())
+}
+
+func (dslTypesFunc) Type(stack *quasigo.ValueStack) {
+	stack.Push(stack.Pop().(*types.Func).Type())
+}
+
+type dslTypesTypeName struct{}
+
+func (native dslTypesTypeName) funcs() map[string]func(*quasigo.ValueStack) {
+	return map[string]func(*quasigo.ValueStack){
+		"Name":     native.Name,
+		"Exported": native.Exported,
+		"Pkg":      native.Pkg,
+	}
+}
+
+func (dslTypesTypeName) Name(stack *quasigo.ValueStack) {
+	stack.Push(stack.Pop().(*types.TypeName).Name())
+}
+
+func (dslTypesTypeName) Exported(stack *quasigo.ValueStack) {
+	stack.Push(stack.Pop().(*types.TypeName).Exported())
+}
+
+func (dslTypesTypeName) Pkg(stack *quasigo.ValueStack) {
+	stack.Push(stack.Pop().(*types.TypeName).Pkg())
+}
+
+// dslTypesPackageObject implements the types.Package type.
+// dslTypesPackage name is already taken by the types package functions.
+type dslTypesPackageObject struct{}
+
+func (native dslTypesPackageObject) funcs() map[string]func(*quasigo.ValueStack) {
+	return map[string]func(*quasigo.ValueStack){
+		"Path": native.Path,
+		"Name": native.Name,
+	}
+}
+
+func (dslTypesPackageObject) Path(stack *quasigo.ValueStack) {
+	stack.Push(stack.Pop().(*types.Package).Path())
+}
+
+func (dslTypesPackageObject) Name(stack *quasigo.ValueStack) {
+	stack.Push(stack.Pop().(*types.Package).Name())
+}
+
 type dslDoContext struct{}
 
 func (native dslDoContext) funcs() map[string]func(*quasigo.ValueStack) {
@@ -334,8 +550,10 @@ type dslDoVar struct{}
 
 func (native dslDoVar) funcs() map[string]func(*quasigo.ValueStack) {
 	return map[string]func(*quasigo.ValueStack){
-		"Text": native.Text,
-		"Type": native.Type,
+		"Text":  native.Text,
+		"Type":  native.Type,
+		"Node":  native.Node,
+		"Value": native.Value,
 	}
 }
 
@@ -351,6 +569,17 @@ func (dslDoVar) Type(stack *quasigo.ValueStack) {
 	stack.Push(params.typeofNode(params.subNode(v.name)))
 }
 
+func (dslDoVar) Node(stack *quasigo.ValueStack) {
+	v := stack.Pop().(*dslDoVarRepr)
+	pushExpr(stack, v.params.subExpr(v.name))
+}
+
+func (dslDoVar) Value(stack *quasigo.ValueStack) {
+	v := stack.Pop().(*dslDoVarRepr)
+	params := v.params
+	stack.Push(params.constValueOf(params.subExpr(v.name)))
+}
+
 type dslVarFilterContext struct {
 	state *engineState
 }
@@ -358,6 +587,8 @@ type dslVarFilterContext struct {
 func (native dslVarFilterContext) funcs() map[string]func(*quasigo.ValueStack) {
 	return map[string]func(*quasigo.ValueStack){
 		"Type":         native.Type,
+		"Node":         native.Node,
+		"Value":        native.Value,
 		"SizeOf":       native.SizeOf,
 		"GetType":      native.GetType,
 		"GetInterface": native.GetInterface,
@@ -370,6 +601,16 @@ func (dslVarFilterContext) Type(stack *quasigo.ValueStack) {
 	stack.Push(typ)
 }
 
+func (dslVarFilterContext) Node(stack *quasigo.ValueStack) {
+	params := stack.Pop().(*filterParams)
+	pushExpr(stack, params.subExpr(params.varname))
+}
+
+func (dslVarFilterContext) Value(stack *quasigo.ValueStack) {
+	params := stack.Pop().(*filterParams)
+	stack.Push(params.constValueOf(params.subExpr(params.varname)))
+}
+
 func (native dslVarFilterContext) SizeOf(stack *quasigo.ValueStack) {
 	typ := stack.Pop().(types.Type)
 	params := stack.Pop().(*filterParams)
@@ -399,3 +640,263 @@ func (native dslVarFilterContext) GetInterface(stack *quasigo.ValueStack) {
 	}
 	stack.Push((*types.Interface)(nil)) // Not found or not an interface
 }
+
+// pushExpr pushes e as an ast.Expr interface value.
+// A nil e is pushed as an untyped nil, so it compares equal to nil.
+func pushExpr(stack *quasigo.ValueStack, e ast.Expr) {
+	if e == nil {
+		stack.Push(nil)
+		return
+	}
+	stack.Push(e)
+}
+
+type dslAstIdent struct{}
+
+func (native dslAstIdent) funcs() map[string]func(*quasigo.ValueStack) {
+	return map[string]func(*quasigo.ValueStack){
+		"Name": native.Name,
+	}
+}
+
+func (dslAstIdent) Name(stack *quasigo.ValueStack) {
+	stack.Push(stack.Pop().(*ast.Ident).Name)
+}
+
+type dslAstBasicLit struct{}
+
+func (native dslAstBasicLit) funcs() map[string]func(*quasigo.ValueStack) {
+	return map[string]func(*quasigo.ValueStack){
+		"Kind":  native.Kind,
+		"Value": native.Value,
+	}
+}
+
+func (dslAstBasicLit) Kind(stack *quasigo.ValueStack) {
+	stack.Push(stack.Pop().(*ast.BasicLit).Kind.String())
+}
+
+func (dslAstBasicLit) Value(stack *quasigo.ValueStack) {
+	stack.Push(stack.Pop().(*ast.BasicLit).Value)
+}
+
+type dslAstCallExpr struct{}
+
+func (native dslAstCallExpr) funcs() map[string]func(*quasigo.ValueStack) {
+	return map[string]func(*quasigo.ValueStack){
+		"Fun":         native.Fun,
+		"NumArgs":     native.NumArgs,
+		"Arg":         native.Arg,
+		"HasEllipsis": native.HasEllipsis,
+	}
+}
+
+func (dslAstCallExpr) Fun(stack *quasigo.ValueStack) {
+	pushExpr(stack, stack.Pop().(*ast.CallExpr).Fun)
+}
+
+func (dslAstCallExpr) NumArgs(stack *quasigo.ValueStack) {
+	stack.PushInt(len(stack.Pop().(*ast.CallExpr).Args))
+}
+
+func (dslAstCallExpr) Arg(stack *quasigo.ValueStack) {
+	i := stack.PopInt()
+	call := stack.Pop().(*ast.CallExpr)
+	pushExpr(stack, call.Args[i])
+}
+
+func (dslAstCallExpr) HasEllipsis(stack *quasigo.ValueStack) {
+	stack.Push(stack.Pop().(*ast.CallExpr).Ellipsis.IsValid())
+}
+
+type dslAstSelectorExpr struct{}
+
+func (native dslAstSelectorExpr) funcs() map[string]func(*quasigo.ValueStack) {
+	return map[string]func(*quasigo.ValueStack){
+		"X":   native.X,
+		"Sel": native.Sel,
+	}
+}
+
+func (dslAstSelectorExpr) X(stack *quasigo.ValueStack) {
+	pushExpr(stack, stack.Pop().(*ast.SelectorExpr).X)
+}
+
+func (dslAstSelectorExpr) Sel(stack *quasigo.ValueStack) {
+	stack.Push(stack.Pop().(*ast.SelectorExpr).Sel)
+}
+
+type dslAstCompositeLit struct{}
+
+func (native dslAstCompositeLit) funcs() map[string]func(*quasigo.ValueStack) {
+	return map[string]func(*quasigo.ValueStack){
+		"Type":    native.Type,
+		"NumElts": native.NumElts,
+		"Elt":     native.Elt,
+	}
+}
+
+func (dslAstCompositeLit) Type(stack *quasigo.ValueStack) {
+	pushExpr(stack, stack.Pop().(*ast.CompositeLit).Type)
+}
+
+func (dslAstCompositeLit) NumElts(stack *quasigo.ValueStack) {
+	stack.PushInt(len(stack.Pop().(*ast.CompositeLit).Elts))
+}
+
+func (dslAstCompositeLit) Elt(stack *quasigo.ValueStack) {
+	i := stack.PopInt()
+	lit := stack.Pop().(*ast.CompositeLit)
+	pushExpr(stack, lit.Elts[i])
+}
+
+type dslAstFuncLit struct{}
+
+func (native dslAstFuncLit) funcs() map[string]func(*quasigo.ValueStack) {
+	return map[string]func(*quasigo.ValueStack){
+		"NumParams":  native.NumParams,
+		"NumResults": native.NumResults,
+		"NumStmts":   native.NumStmts,
+	}
+}
+
+func (dslAstFuncLit) NumParams(stack *quasigo.ValueStack) {
+	stack.PushInt(stack.Pop().(*ast.FuncLit).Type.Params.NumFields())
+}
+
+func (dslAstFuncLit) NumResults(stack *quasigo.ValueStack) {
+	// Results is nil for functions without results.
+	stack.PushInt(stack.Pop().(*ast.FuncLit).Type.Results.NumFields())
+}
+
+func (dslAstFuncLit) NumStmts(stack *quasigo.ValueStack) {
+	stack.PushInt(len(stack.Pop().(*ast.FuncLit).Body.List))
+}
+
+type dslAstPackage struct{}
+
+func (native dslAstPackage) funcs() map[string]func(*quasigo.ValueStack) {
+	return map[string]func(*quasigo.ValueStack){
+		"AsIdent":        native.AsIdent,
+		"AsBasicLit":     native.AsBasicLit,
+		"AsCallExpr":     native.AsCallExpr,
+		"AsSelectorExpr": native.AsSelectorExpr,
+		"AsCompositeLit": native.AsCompositeLit,
+		"AsFuncLit":      native.AsFuncLit,
+		"Unparen":        native.Unparen,
+	}
+}
+
+func (dslAstPackage) AsIdent(stack *quasigo.ValueStack) {
+	e, _ := stack.Pop().(*ast.Ident)
+	stack.Push(e)
+}
+
+func (dslAstPackage) AsBasicLit(stack *quasigo.ValueStack) {
+	e, _ := stack.Pop().(*ast.BasicLit)
+	stack.Push(e)
+}
+
+func (dslAstPackage) AsCallExpr(stack *quasigo.ValueStack) {
+	e, _ := stack.Pop().(*ast.CallExpr)
+	stack.Push(e)
+}
+
+func (dslAstPackage) AsSelectorExpr(stack *quasigo.ValueStack) {
+	e, _ := stack.Pop().(*ast.SelectorExpr)
+	stack.Push(e)
+}
+
+func (dslAstPackage) AsCompositeLit(stack *quasigo.ValueStack) {
+	e, _ := stack.Pop().(*ast.CompositeLit)
+	stack.Push(e)
+}
+
+func (dslAstPackage) AsFuncLit(stack *quasigo.ValueStack) {
+	e, _ := stack.Pop().(*ast.FuncLit)
+	stack.Push(e)
+}
+
+func (dslAstPackage) Unparen(stack *quasigo.ValueStack) {
+	e, _ := stack.Pop().(ast.Expr)
+	if e == nil {
+		stack.Push(nil)
+		return
+	}
+	stack.Push(astutil.Unparen(e))
+}
+
+type dslConstantValue struct{}
+
+func (native dslConstantValue) funcs() map[string]func(*quasigo.ValueStack) {
+	return map[string]func(*quasigo.ValueStack){
+		"Kind":        native.Kind,
+		"String":      native.String,
+		"ExactString": native.ExactString,
+	}
+}
+
+func (dslConstantValue) Kind(stack *quasigo.ValueStack) {
+	stack.PushInt(int(stack.Pop().(constant.Value).Kind()))
+}
+
+func (dslConstantValue) String(stack *quasigo.ValueStack) {
+	stack.Push(stack.Pop().(constant.Value).String())
+}
+
+func (dslConstantValue) ExactString(stack *quasigo.ValueStack) {
+	stack.Push(stack.Pop().(constant.Value).ExactString())
+}
+
+// dslConstantPackage accessors never panic, unlike their go/constant counterparts.
+type dslConstantPackage struct{}
+
+func (native dslConstantPackage) funcs() map[string]func(*quasigo.ValueStack) {
+	return map[string]func(*quasigo.ValueStack){
+		"BoolVal":    native.BoolVal,
+		"StringVal":  native.StringVal,
+		"IntVal":     native.IntVal,
+		"Float64Val": native.Float64Val,
+	}
+}
+
+func (dslConstantPackage) BoolVal(stack *quasigo.ValueStack) {
+	x := stack.Pop().(constant.Value)
+	stack.Push(x.Kind() == constant.Bool && constant.BoolVal(x))
+}
+
+func (dslConstantPackage) StringVal(stack *quasigo.ValueStack) {
+	x := stack.Pop().(constant.Value)
+	if x.Kind() != constant.String {
+		stack.Push("")
+		return
+	}
+	stack.Push(constant.StringVal(x))
+}
+
+func (dslConstantPackage) IntVal(stack *quasigo.ValueStack) {
+	x := constant.ToInt(stack.Pop().(constant.Value))
+	if x.Kind() != constant.Int {
+		stack.PushInt(0)
+		stack.Push(false)
+		return
+	}
+	v, exact := constant.Int64Val(x)
+	if int64(int(v)) != v {
+		v, exact = 0, false
+	}
+	stack.PushInt(int(v))
+	stack.Push(exact)
+}
+
+func (dslConstantPackage) Float64Val(stack *quasigo.ValueStack) {
+	x := constant.ToFloat(stack.Pop().(constant.Value))
+	if x.Kind() != constant.Float {
+		stack.Push(0.0)
+		stack.Push(false)
+		return
+	}
+	v, exact := constant.Float64Val(x)
+	stack.Push(v)
+	stack.Push(exact)
+}
@@ -0,0 +1,150 @@
+package ruleguard
+
+import (
+	"fmt"
+	"go/ast"
+	"path/filepath"
+	"strings"
+)
+
+// packageMatches collects the package-scoped rules matches
+// while the package files are being processed.
+//
+// After all files are processed, report() evaluates
+// the PackageWhere() conditions for every matches group.
+type packageMatches struct {
+	groups []*packageMatchGroup
+	byKey  map[packageMatchKey]*packageMatchGroup
+
+	// files are the ruleguard:ignore directives of the processed files.
+	// They're applied to the matches only when they're reported,
+	// so the directives for the rejected matches are not marked as used.
+	files [][]suppression
+}
+
+type packageMatchKey struct {
+	scope *packageScope
+	key   string
+}
+
+// packageMatchGroup is a set of matches that have identical groupBy vars text.
+type packageMatchGroup struct {
+	scope   *packageScope
+	matches []ReportData
+
+	// matchFiles maps every match to its pm.files index.
+	matchFiles []int
+
+	numFiles     int
+	lastFilename string
+}
+
+func newPackageMatches() *packageMatches {
+	return &packageMatches{byKey: make(map[packageMatchKey]*packageMatchGroup)}
+}
+
+// addFile starts the next file processing.
+// The suppressions slice is shared with the file runner,
+// so the directives used by the other rules are marked as well.
+func (pm *packageMatches) addFile(suppressions []suppression) {
+	pm.files = append(pm.files, suppressions)
+}
+
+func (pm *packageMatches) add(scope *packageScope, key, filename string, data *ReportData) {
+	k := packageMatchKey{scope: scope, key: key}
+	g := pm.byKey[k]
+	if g == nil {
+		g = &packageMatchGroup{scope: scope}
+		pm.byKey[k] = g
+		pm.groups = append(pm.groups, g)
+	}
+	// Files are processed one by one, so it's enough
+	// to compare the filename with the previous one.
+	if g.lastFilename != filename {
+		g.lastFilename = filename
+		g.numFiles++
+	}
+	g.matches = append(g.matches, data.clone())
+	g.matchFiles = append(g.matchFiles, len(pm.files)-1)
+}
+
+func (pm *packageMatches) report(ctx *RunContext) {
+	var stats map[ruleStatsKey]*RuleStat
+	if ctx.Stats != nil {
+		stats = make(map[ruleStatsKey]*RuleStat)
+	}
+	ruleStat := func(info GoRuleInfo) *RuleStat {
+		key := ruleStatsKey{group: info.Group, line: info.Line}
+		st := stats[key]
+		if st == nil {
+			st = &RuleStat{Group: info.Group, Line: info.Line, Rejects: make(map[string]int)}
+			stats[key] = st
+		}
+		return st
+	}
+
+	params := filterParams{ctx: ctx}
+	for _, g := range pm.groups {
+		params.packageMatches = g
+		filterResult := g.scope.filter.fn(&params)
+		if !filterResult.Matched() {
+			for i := range g.matches {
+				info := g.matches[i].RuleInfo
+				if stats != nil {
+					ruleStat(info).Rejects[filterResult.RejectReason()]++
+				}
+				if info.Group.Name == ctx.Debug {
+					pos := ctx.Fset.Position(g.matches[i].Node.Pos())
+					ctx.DebugPrint(fmt.Sprintf("%s:%d: [%s:%d] rejected by %s",
+						pos.Filename, pos.Line, filepath.Base(info.Group.Filename), info.Line, filterResult.RejectReason()))
+				}
+			}
+			continue
+		}
+		for i := range g.matches {
+			data := &g.matches[i]
+			if isSuppressed(ctx.Fset, pm.files[g.matchFiles[i]], data.RuleInfo.Group, data.Node) {
+				continue
+			}
+			if stats != nil {
+				ruleStat(data.RuleInfo).Reports++
+			}
+			ctx.Report(data)
+		}
+	}
+
+	if stats != nil {
+		ctx.Stats.merge(stats)
+	}
+}
+
+func (pm *packageMatches) reportUnusedSuppressions(ctx *RunContext, rules *goRuleSet) {
+	for _, suppressions := range pm.files {
+		reportUnusedSuppressions(ctx, rules, suppressions)
+	}
+}
+
+// packageMatchKey returns the matches group key for the current match.
+func (rr *rulesRunner) packageMatchKey(scope *packageScope, m matchData) string {
+	switch len(scope.groupBy) {
+	case 0:
+		return ""
+	case 1:
+		return rr.nodeString(rr.packageGroupByNode(scope.groupBy[0], m))
+	default:
+		parts := make([]string, len(scope.groupBy))
+		for i, v := range scope.groupBy {
+			parts[i] = rr.nodeString(rr.packageGroupByNode(v, m))
+		}
+		// Go code can't contain the NUL byte, so it's a safe separator.
+		return strings.Join(parts, "\x00")
+	}
+}
+
+func (rr *rulesRunner) packageGroupByNode(varname string, m matchData) ast.Node {
+	if varname == "$$" {
+		return m.Node()
+	}
+	n, _ := m.CapturedByName(varname)
+	return n
+}
@@ -0,0 +1,167 @@
+package ruleguard
+
+import (
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestRunPackage(t *testing.T) {
+	rules := `
+	package gorules
+
+	import "github.com/quasilyte/go-ruleguard/dsl"
+
+	func initInSeveralFiles(m dsl.Matcher) {
+		m.Match("func init() { $*_ }").
+			PackageWhere(m.Matches().Files > 1).
+			Report("init in several files")
+	}
+	`
+	sources := map[string]string{
+		"a.go": "package example\nfunc init() {}\n",
+		"b.go": "package example\nfunc init() {}\n",
+	}
+
+	e := NewEngine()
+	if err := e.Load(&LoadContext{Fset: token.NewFileSet()}, "rules.go", strings.NewReader(rules)); err != nil {
+		t.Fatalf("load rules: %v", err)
+	}
+
+	fset := token.NewFileSet()
+	var files []*ast.File
+	for _, filename := range []string{"a.go", "b.go"} {
+		f, err := parser.ParseFile(fset, filename, sources[filename], 0)
+		if err != nil {
+			t.Fatalf("parse: %v", err)
+		}
+		files = append(files, f)
+	}
+	typesInfo := &types.Info{
+		Types: map[ast.Expr]types.TypeAndValue{},
+		Uses:  map[*ast.Ident]types.Object{},
+		Defs:  map[*ast.Ident]types.Object{},
+	}
+	pkg, err := (&types.Config{}).Check("example", fset, files, typesInfo)
+	if err != nil {
+		t.Fatalf("typecheck: %v", err)
+	}
+
+	var reports []string
+	ctx := &RunContext{
+		Pkg:   pkg,
+		Types: typesInfo,
+		Fset:  fset,
+		Report: func(data *ReportData) {
+			reports = append(reports, fset.Position(data.Node.Pos()).Filename)
+		},
+	}
+
+	if !e.HasPackageRules() {
+		t.Fatal("HasPackageRules(): expected true")
+	}
+	for _, f := range files {
+		if err := e.Run(ctx, f); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if len(reports) != 0 {
+		t.Fatalf("Run() executed a package-scoped rule: %v", reports)
+	}
+
+	if err := e.RunPackage(ctx, files[:1]); err != nil {
+		t.Fatal(err)
+	}
+	if len(reports) != 0 {
+		t.Fatalf("expected no reports for a single file, have %v", reports)
+	}
+
+	if err := e.RunPackage(ctx, files); err != nil {
+		t.Fatal(err)
+	}
+	if strings.Join(reports, " ") != "a.go b.go" {
+		t.Fatalf("unexpected reports: %v", reports)
+	}
+}
+
+func TestRunPackageSuppressions(t *testing.T) {
+	rules := `
+	package gorules
+
+	import "github.com/quasilyte/go-ruleguard/dsl"
+
+	func manyCalls(m dsl.Matcher) {
+		m.Match("f($_)").
+			PackageWhere(m.Matches().Count > 1).
+			Report("many f calls")
+	}
+	`
+	sources := map[string]string{
+		"a.go": "package example\nfunc f(int) {}\nfunc a() {\n\tf(1) //ruleguard:ignore manyCalls\n}\n",
+		"b.go": "package example\nfunc b() {\n\tf(2)\n}\n",
+	}
+
+	e := NewEngine()
+	if err := e.Load(&LoadContext{Fset: token.NewFileSet()}, "rules.go", strings.NewReader(rules)); err != nil {
+		t.Fatalf("load rules: %v", err)
+	}
+
+	runPackage := func(filenames ...string) []string {
+		fset := token.NewFileSet()
+		var files []*ast.File
+		for _, filename := range filenames {
+			f, err := parser.ParseFile(fset, filename, sources[filename], parser.ParseComments)
+			if err != nil {
+				t.Fatalf("parse: %v", err)
+			}
+			files = append(files, f)
+		}
+		typesInfo := &types.Info{
+			Types: map[ast.Expr]types.TypeAndValue{},
+			Uses:  map[*ast.Ident]types.Object{},
+			Defs:  map[*ast.Ident]types.Object{},
+		}
+		var typecheker types.Config
+		typecheker.Error = func(error) {} // Undefined f in b.go without a.go
+		pkg, _ := typecheker.Check("example", fset, files, typesInfo)
+		var reports []string
+		ctx := &RunContext{
+			Pkg:   pkg,
+			Types: typesInfo,
+			Fset:  fset,
+			Report: func(data *ReportData) {
+				pos := fset.Position(data.Node.Pos())
+				reports = append(reports, fmt.Sprintf("%s:%d: %s", pos.Filename, pos.Line, data.Message))
+			},
+			ReportUnusedSuppression: func(comment *ast.Comment) {
+				pos := fset.Position(comment.Pos())
+				reports = append(reports, fmt.Sprintf("%s:%d: unused %s", pos.Filename, pos.Line, comment.Text))
+			},
+		}
+		if err := e.RunPackage(ctx, files); err != nil {
+			t.Fatal(err)
+		}
+		return reports
+	}
+
+	// The suppressed match is still counted by the PackageWhere() filter.
+	have := runPackage("a.go", "b.go")
+	want := []string{"b.go:3: many f calls"}
+	if diff := cmp.Diff(want, have); diff != "" {
+		t.Errorf("a.go+b.go reports mismatch (-want +have):\n%s", diff)
+	}
+
+	// The only match is rejected by the PackageWhere() filter,
+	// so the directive is not used.
+	have = runPackage("a.go")
+	want = []string{"a.go:4: unused //ruleguard:ignore manyCalls"}
+	if diff := cmp.Diff(want, have); diff != "" {
+		t.Errorf("a.go reports mismatch (-want +have):\n%s", diff)
+	}
+}
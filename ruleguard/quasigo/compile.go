@@ -33,22 +33,40 @@ func compile(ctx *CompileContext, fn *ast.FuncDecl) (compiled *Func, err error)
 }
 
 func compileFunc(ctx *CompileContext, fn *ast.FuncDecl) *Func {
-	cl := compiler{
+	fnObj := ctx.Types.ObjectOf(fn.Name).(*types.Func)
+	cl := newCompiler(ctx, fnObj.Type().(*types.Signature))
+	cl.fnObj = fnObj
+	cl.root = fn.Body
+	return cl.compileFunc(fn.Name, ctx.Package.Path()+"."+fn.Name.String(), fn.Body, nil)
+}
+
+func newCompiler(ctx *CompileContext, fnType *types.Signature) *compiler {
+	return &compiler{
 		ctx:              ctx,
-		fnType:           ctx.Types.ObjectOf(fn.Name).Type().(*types.Signature),
+		fnType:           fnType,
 		constantsPool:    make(map[interface{}]int),
 		intConstantsPool: make(map[int]int),
 		locals:           make(map[string]int),
 	}
-	return cl.compileFunc(fn)
 }
 
 type compiler struct {
 	ctx *CompileContext
 
+	fnName  string
 	fnType  *types.Signature
 	retType types.Type
 
+	// fnObj is a compiled func object; it's used to detect the recursive calls.
+	// For func literals it's nil.
+	fnObj *types.Func
+
+	// root is a body of the top-level func declaration.
+	// Func literals share it with their enclosing func.
+	root *ast.BlockStmt
+
+	numFuncLits int
+
 	lastOp opcode
 
 	locals           map[string]int
@@ -77,28 +95,42 @@ type compileError string
 
 func (e compileError) Error() string { return string(e) }
 
-func (cl *compiler) compileFunc(fn *ast.FuncDecl) *Func {
-	switch cl.fnType.Results().Len() {
+// compileFunc compiles a func declaration or a func literal body.
+// The captured variables of a func literal become its extra params.
+func (cl *compiler) compileFunc(fn ast.Node, name string, body *ast.BlockStmt, captured []*types.Var) *Func {
+	cl.fnName = name
+	results := cl.fnType.Results()
+	switch results.Len() {
 	case 0:
 		cl.retType = voidType
 	case 1:
-		cl.retType = cl.fnType.Results().At(0).Type()
+		cl.retType = results.At(0).Type()
 	default:
-		panic(cl.errorf(fn.Name, "multi-result functions are not supported"))
+		cl.retType = results
 	}
 
-	if !cl.isSupportedType(cl.retType) {
-		panic(cl.errorUnsupportedType(fn.Name, cl.retType, "function result"))
+	numObjectResults := 0
+	numIntResults := 0
+	for i := 0; i < results.Len(); i++ {
+		typ := results.At(i).Type()
+		if !cl.isSupportedType(typ) {
+			panic(cl.errorUnsupportedType(fn, typ, "function result"))
+		}
+		if typeIsInt(typ) {
+			numIntResults++
+		} else {
+			numObjectResults++
+		}
 	}
 
-	cl.params = make(map[string]int, cl.fnType.Params().Len())
-	cl.intParams = make(map[string]int, cl.fnType.Params().Len())
+	cl.params = make(map[string]int, cl.fnType.Params().Len()+len(captured))
+	cl.intParams = make(map[string]int, cl.fnType.Params().Len()+len(captured))
 	for i := 0; i < cl.fnType.Params().Len(); i++ {
 		p := cl.fnType.Params().At(i)
 		paramName := p.Name()
 		paramType := p.Type()
-		if !cl.isSupportedType(paramType) {
-			panic(cl.errorUnsupportedType(fn.Name, paramType, paramName+" param"))
+		if !cl.isSupportedType(paramType) && !typeIsFunc(paramType) {
+			panic(cl.errorUnsupportedType(fn, paramType, paramName+" param"))
 		}
 		if typeIsInt(paramType) {
 			cl.intParams[paramName] = len(cl.intParams)
@@ -106,6 +138,20 @@ func (cl *compiler) compileFunc(fn *ast.FuncDecl) *Func {
 			cl.params[paramName] = len(cl.params)
 		}
 	}
+	numCaptured := 0
+	numIntCaptured := 0
+	for _, v := range captured {
+		if cl.isParamName(v.Name()) {
+			panic(cl.errorf(fn, "%s variable shadowing is not allowed", v.Name()))
+		}
+		if typeIsInt(v.Type()) {
+			cl.intParams[v.Name()] = len(cl.intParams)
+			numIntCaptured++
+		} else {
+			cl.params[v.Name()] = len(cl.params)
+			numCaptured++
+		}
+	}
 
 	dbg := funcDebugInfo{
 		paramNames:    make([]string, len(cl.params)),
@@ -118,7 +164,7 @@ func (cl *compiler) compileFunc(fn *ast.FuncDecl) *Func {
 		dbg.intParamNames[i] = paramName
 	}
 
-	cl.compileStmt(fn.Body)
+	cl.compileStmt(body)
 	if cl.retType == voidType {
 		cl.emit(opReturn)
 	}
@@ -129,7 +175,13 @@ func (cl *compiler) compileFunc(fn *ast.FuncDecl) *Func {
 		intConstants:    cl.intConstants,
 		numObjectParams: len(cl.params),
 		numIntParams:    len(cl.intParams),
-		name:            cl.ctx.Package.Path() + "." + fn.Name.String(),
+		numCaptured:     numCaptured,
+		numIntCaptured:  numIntCaptured,
+		name:            name,
+	}
+	if results.Len() > 1 {
+		compiled.numObjectResults = numObjectResults
+		compiled.numIntResults = numIntResults
 	}
 	if len(cl.locals) != 0 {
 		dbg.localNames = make([]string, len(cl.locals))
@@ -159,6 +211,9 @@ func (cl *compiler) compileStmt(stmt ast.Stmt) {
 	case *ast.ForStmt:
 		cl.compileForStmt(stmt)
 
+	case *ast.RangeStmt:
+		cl.compileRangeStmt(stmt)
+
 	case *ast.BranchStmt:
 		cl.compileBranchStmt(stmt)
 
@@ -196,6 +251,8 @@ func (cl *compiler) compileBranchStmt(branch *ast.BranchStmt) {
 	switch branch.Tok {
 	case token.BREAK:
 		cl.emitJump(opJump, cl.breakTarget)
+	case token.CONTINUE:
+		cl.emitJump(opJump, cl.continueTarget)
 	default:
 		panic(cl.errorf(branch, "can't compile %s yet", branch.Tok))
 	}
@@ -250,6 +307,132 @@ func (cl *compiler) compileForStmt(stmt *ast.ForStmt) {
 	cl.continueTarget = prevContinueTarget
 }
 
+func (cl *compiler) compileRangeStmt(stmt *ast.RangeStmt) {
+	labelBreak := cl.newLabel()
+	labelContinue := cl.newLabel()
+	labelBody := cl.newLabel()
+	labelCond := cl.newLabel()
+	prevBreakTarget := cl.breakTarget
+	prevContinueTarget := cl.continueTarget
+	cl.breakTarget = labelBreak
+	cl.continueTarget = labelContinue
+
+	// Maps are iterated over their sorted keys slice.
+	// The hidden idx variable is an index inside that slice
+	// (or inside the ranged slice or string).
+	typ := cl.ctx.Types.TypeOf(stmt.X).Underlying()
+	var m int
+	coll := cl.allocTemp(stmt, "coll")
+	switch typ := typ.(type) {
+	case *types.Map:
+		m = cl.allocTemp(stmt, "map")
+		cl.compileExpr(stmt.X)
+		cl.emit8(opSetLocal, m)
+		cl.emit8(opPushLocal, m)
+		cl.emit(opMapKeys)
+		cl.emit8(opSetLocal, coll)
+	case *types.Slice:
+		cl.compileExpr(stmt.X)
+		cl.emit8(opSetLocal, coll)
+	default:
+		if !typeIsString(typ) {
+			panic(cl.errorf(stmt.X, "can't range over %s", typ))
+		}
+		cl.compileExpr(stmt.X)
+		cl.emit8(opSetLocal, coll)
+	}
+	idx := cl.allocTemp(stmt, "idx")
+	cl.emit8(opPushIntConst, cl.internIntConstant(0))
+	cl.emit8(opSetIntLocal, idx)
+
+	key := cl.rangeVarLocal(stmt.Key, stmt.Tok)
+	value := cl.rangeVarLocal(stmt.Value, stmt.Tok)
+
+	cl.emitJump(opJump, labelCond)
+	cl.bindLabel(labelBody)
+	var runeSize int
+	switch typ := typ.(type) {
+	case *types.Map:
+		if key != -1 {
+			cl.emit8(opPushLocal, coll)
+			cl.emit8(opPushIntLocal, idx)
+			cl.emit(opStringSliceIndex)
+			cl.emit8(opSetLocal, key)
+		}
+		if value != -1 {
+			cl.emit8(opPushLocal, m)
+			cl.emit8(opPushLocal, coll)
+			cl.emit8(opPushIntLocal, idx)
+			cl.emit(opStringSliceIndex)
+			cl.emitMapIndex(typ)
+			cl.emit8(pickOp(typeIsInt(typ.Elem()), opSetIntLocal, opSetLocal), value)
+		}
+	case *types.Slice:
+		if key != -1 {
+			cl.emit8(opPushIntLocal, idx)
+			cl.emit8(opSetIntLocal, key)
+		}
+		if value != -1 {
+			cl.emit8(opPushLocal, coll)
+			cl.emit8(opPushIntLocal, idx)
+			cl.emitSliceIndex(typ)
+			cl.emit8(pickOp(typeIsInt(typ.Elem()), opSetIntLocal, opSetLocal), value)
+		}
+	default:
+		runeSize = cl.allocTemp(stmt, "size")
+		if value == -1 {
+			value = cl.allocTemp(stmt, "rune")
+		}
+		cl.emit8(opPushLocal, coll)
+		cl.emit8(opPushIntLocal, idx)
+		cl.emit(opStringDecodeRune)
+		cl.emit8(opSetIntLocal, runeSize)
+		cl.emit8(opSetIntLocal, value)
+		if key != -1 {
+			cl.emit8(opPushIntLocal, idx)
+			cl.emit8(opSetIntLocal, key)
+		}
+	}
+
+	cl.compileStmt(stmt.Body)
+
+	cl.bindLabel(labelContinue)
+	if typeIsString(typ) {
+		cl.emit8(opPushIntLocal, idx)
+		cl.emit8(opPushIntLocal, runeSize)
+		cl.emit(opAdd)
+		cl.emit8(opSetIntLocal, idx)
+	} else {
+		cl.emit8(opIncLocal, idx)
+	}
+	cl.bindLabel(labelCond)
+	cl.emit8(opPushIntLocal, idx)
+	cl.emit8(opPushLocal, coll)
+	cl.emit(pickOp(typeIsString(typ), opStringLen, opSliceLen))
+	cl.emit(opLtInt)
+	cl.emitJump(opJumpTrue, labelBody)
+	cl.bindLabel(labelBreak)
+
+	cl.breakTarget = prevBreakTarget
+	cl.continueTarget = prevContinueTarget
+}
+
+// rangeVarLocal returns a local variable index for the range loop key or value.
+// For the omitted and blank variables, -1 is returned.
+func (cl *compiler) rangeVarLocal(e ast.Expr, tok token.Token) int {
+	if e == nil || identName(e) == "_" {
+		return -1
+	}
+	varname, ok := e.(*ast.Ident)
+	if !ok {
+		panic(cl.errorf(e, "can assign only to simple variables"))
+	}
+	if tok == token.DEFINE {
+		return cl.defineLocal(varname)
+	}
+	return cl.getLocal(varname, varname.String())
+}
+
 func (cl *compiler) compileIfStmt(stmt *ast.IfStmt) {
 	if stmt.Else == nil {
 		labelEnd := cl.newLabel()
@@ -277,6 +460,10 @@ func (cl *compiler) compileAssignStmt(assign *ast.AssignStmt) {
 	if len(assign.Rhs) != 1 {
 		panic(cl.errorf(assign, "only single right operand is allowed in assignments"))
 	}
+	if index, ok := assign.Lhs[0].(*ast.IndexExpr); ok && len(assign.Lhs) == 1 && assign.Tok == token.ASSIGN {
+		cl.compileIndexAssign(index, assign.Rhs[0])
+		return
+	}
 	for _, lhs := range assign.Lhs {
 		_, ok := lhs.(*ast.Ident)
 		if !ok {
@@ -285,33 +472,76 @@ func (cl *compiler) compileAssignStmt(assign *ast.AssignStmt) {
 	}
 
 	rhs := assign.Rhs[0]
-	cl.compileExpr(rhs)
-
-	if assign.Tok == token.DEFINE {
-		for i := len(assign.Lhs) - 1; i >= 0; i-- {
-			varname := assign.Lhs[i].(*ast.Ident)
-			typ := cl.ctx.Types.TypeOf(varname)
-			if _, ok := cl.locals[varname.String()]; ok {
-				panic(cl.errorf(varname, "%s variable shadowing is not allowed", varname))
-			}
-			if !cl.isSupportedType(typ) {
-				panic(cl.errorUnsupportedType(varname, typ, varname.String()+" local variable"))
-			}
-			if len(cl.locals) == maxFuncLocals {
-				panic(cl.errorf(varname, "can't define %s: too many locals", varname))
-			}
-			id := len(cl.locals)
-			cl.locals[varname.String()] = id
-			cl.emit8(pickOp(typeIsInt(typ), opSetIntLocal, opSetLocal), id)
+	rhsType := cl.ctx.Types.TypeOf(rhs)
+	if len(assign.Lhs) == 1 {
+		if identName(assign.Lhs[0]) != "_" {
+			cl.compileValue(rhs, cl.ctx.Types.TypeOf(assign.Lhs[0]))
+		} else {
+			cl.compileExpr(rhs)
 		}
 	} else {
-		for i := len(assign.Lhs) - 1; i >= 0; i-- {
-			varname := assign.Lhs[i].(*ast.Ident)
-			typ := cl.ctx.Types.TypeOf(varname)
-			id := cl.getLocal(varname, varname.String())
-			cl.emit8(pickOp(typeIsInt(typ), opSetIntLocal, opSetLocal), id)
+		if _, ok := astutil.Unparen(rhs).(*ast.CallExpr); !ok {
+			panic(cl.errorf(rhs, "can't compile comma-ok expressions yet"))
 		}
+		cl.compileExpr(rhs)
+	}
+
+	for i := len(assign.Lhs) - 1; i >= 0; i-- {
+		varname := assign.Lhs[i].(*ast.Ident)
+		if varname.Name == "_" {
+			typ := rhsType
+			if tuple, ok := rhsType.(*types.Tuple); ok {
+				typ = tuple.At(i).Type()
+			}
+			cl.emit(pickOp(typeIsInt(typ), opPopInt, opPop))
+			continue
+		}
+		typ := cl.ctx.Types.TypeOf(varname)
+		var id int
+		if assign.Tok == token.DEFINE {
+			id = cl.defineLocal(varname)
+		} else {
+			id = cl.getLocal(varname, varname.String())
+		}
+		cl.emit8(pickOp(typeIsInt(typ), opSetIntLocal, opSetLocal), id)
+	}
+}
+
+func (cl *compiler) compileIndexAssign(lhs *ast.IndexExpr, rhs ast.Expr) {
+	typ, ok := cl.ctx.Types.TypeOf(lhs.X).Underlying().(*types.Map)
+	if !ok {
+		panic(cl.errorf(lhs, "can't assign to %s elements yet", cl.ctx.Types.TypeOf(lhs.X)))
+	}
+	cl.compileExpr(lhs.X)
+	cl.compileExpr(lhs.Index)
+	cl.compileValue(rhs, typ.Elem())
+	cl.emit(pickOp(typeIsInt(typ.Elem()), opSetIntMapIndex, opSetMapIndex))
+}
+
+func (cl *compiler) defineLocal(varname *ast.Ident) int {
+	typ := cl.ctx.Types.TypeOf(varname)
+	if _, ok := cl.locals[varname.String()]; ok {
+		panic(cl.errorf(varname, "%s variable shadowing is not allowed", varname))
 	}
+	if !cl.isSupportedType(typ) && !typeIsFunc(typ) {
+		panic(cl.errorUnsupportedType(varname, typ, varname.String()+" local variable"))
+	}
+	return cl.allocLocal(varname, varname.String())
+}
+
+// allocTemp allocates a hidden local variable.
+// These names can't clash with the user-defined variables.
+func (cl *compiler) allocTemp(n ast.Node, name string) int {
+	return cl.allocLocal(n, fmt.Sprintf("$%s%d", name, len(cl.locals)))
+}
+
+func (cl *compiler) allocLocal(n ast.Node, name string) int {
+	if len(cl.locals) == maxFuncLocals {
+		panic(cl.errorf(n, "can't define %s: too many locals", name))
+	}
+	id := len(cl.locals)
+	cl.locals[name] = id
+	return id
 }
 
 func (cl *compiler) isParamName(varname string) bool {
@@ -345,14 +575,30 @@ func (cl *compiler) compileReturnStmt(ret *ast.ReturnStmt) {
 		panic(cl.errorf(ret, "'naked' return statements are not allowed"))
 	}
 
+	if results, ok := cl.retType.(*types.Tuple); ok {
+		if len(ret.Results) == 1 {
+			// A `return f()` form, f() pushes all results.
+			cl.compileExpr(ret.Results[0])
+		} else {
+			for i, e := range ret.Results {
+				cl.compileValue(e, results.At(i).Type())
+			}
+		}
+		cl.emit(opReturnMulti)
+		return
+	}
+
 	switch {
 	case identName(ret.Results[0]) == "true":
 		cl.emit(opReturnTrue)
 	case identName(ret.Results[0]) == "false":
 		cl.emit(opReturnFalse)
 	default:
-		cl.compileExpr(ret.Results[0])
 		typ := cl.ctx.Types.TypeOf(ret.Results[0])
+		if typeIsFunc(typ) {
+			panic(cl.errorf(ret.Results[0], "can't use func value as %s", cl.retType))
+		}
+		cl.compileExpr(ret.Results[0])
 		cl.emit(pickOp(typeIsInt(typ), opReturnIntTop, opReturnTop))
 	}
 }
@@ -385,6 +631,15 @@ func (cl *compiler) compileExpr(e ast.Expr) {
 	case *ast.SliceExpr:
 		cl.compileSliceExpr(e)
 
+	case *ast.IndexExpr:
+		cl.compileIndexExpr(e)
+
+	case *ast.CompositeLit:
+		cl.compileCompositeLit(e)
+
+	case *ast.FuncLit:
+		cl.compileFuncLit(e)
+
 	case *ast.BinaryExpr:
 		cl.compileBinaryExpr(e)
 
@@ -433,6 +688,8 @@ func (cl *compiler) compileBinaryExpr(e *ast.BinaryExpr) {
 			cl.compileBinaryOp(opNotEqString, e)
 		case typeIsInt(typ):
 			cl.compileBinaryOp(opNotEqInt, e)
+		case typeIsFloat(typ):
+			cl.compileBinaryOp(opNotEqFloat, e)
 		default:
 			panic(cl.errorf(e, "!= is not implemented for %s operands", typ))
 		}
@@ -448,18 +705,20 @@ func (cl *compiler) compileBinaryExpr(e *ast.BinaryExpr) {
 			cl.compileBinaryOp(opEqString, e)
 		case typeIsInt(cl.ctx.Types.TypeOf(e.X)):
 			cl.compileBinaryOp(opEqInt, e)
+		case typeIsFloat(cl.ctx.Types.TypeOf(e.X)):
+			cl.compileBinaryOp(opEqFloat, e)
 		default:
 			panic(cl.errorf(e, "== is not implemented for %s operands", typ))
 		}
 
 	case token.GTR:
-		cl.compileIntBinaryOp(e, opGtInt, typ)
+		cl.compileNumericBinaryOp(e, opGtInt, opGtFloat, typ)
 	case token.GEQ:
-		cl.compileIntBinaryOp(e, opGtEqInt, typ)
+		cl.compileNumericBinaryOp(e, opGtEqInt, opGtEqFloat, typ)
 	case token.LSS:
-		cl.compileIntBinaryOp(e, opLtInt, typ)
+		cl.compileNumericBinaryOp(e, opLtInt, opLtFloat, typ)
 	case token.LEQ:
-		cl.compileIntBinaryOp(e, opLtEqInt, typ)
+		cl.compileNumericBinaryOp(e, opLtEqInt, opLtEqFloat, typ)
 
 	case token.ADD:
 		switch {
@@ -467,27 +726,83 @@ func (cl *compiler) compileBinaryExpr(e *ast.BinaryExpr) {
 			cl.compileBinaryOp(opConcat, e)
 		case typeIsInt(typ):
 			cl.compileBinaryOp(opAdd, e)
+			cl.emitIntWrap(typ)
+		case typeIsFloat(typ):
+			cl.compileBinaryOp(opAddFloat, e)
 		default:
 			panic(cl.errorf(e, "+ is not implemented for %s operands", typ))
 		}
 
 	case token.SUB:
-		cl.compileIntBinaryOp(e, opSub, typ)
+		cl.compileNumericBinaryOp(e, opSub, opSubFloat, typ)
+		cl.emitIntWrap(typ)
+
+	case token.MUL:
+		cl.compileNumericBinaryOp(e, opMul, opMulFloat, typ)
+		cl.emitIntWrap(typ)
+
+	case token.QUO:
+		cl.compileNumericBinaryOp(e, opDiv, opDivFloat, typ)
+		cl.emitIntWrap(typ)
 
 	default:
 		panic(cl.errorf(e, "can't compile binary %s yet", e.Op))
 	}
 }
 
-func (cl *compiler) compileIntBinaryOp(e *ast.BinaryExpr, op opcode, typ types.Type) {
+func (cl *compiler) compileNumericBinaryOp(e *ast.BinaryExpr, intOp, floatOp opcode, typ types.Type) {
 	switch {
 	case typeIsInt(typ):
-		cl.compileBinaryOp(op, e)
+		cl.compileBinaryOp(intOp, e)
+	case typeIsFloat(typ):
+		cl.compileBinaryOp(floatOp, e)
 	default:
 		panic(cl.errorf(e, "%s is not implemented for %s operands", e.Op, typ))
 	}
 }
 
+// emitIntWrap truncates the int stack top value to the typ range.
+// Bytes and runes are stored as ints, so they need it after
+// the arithmetic and conversions to wrap around like in Go.
+func (cl *compiler) emitIntWrap(typ types.Type) {
+	basic, ok := typ.Underlying().(*types.Basic)
+	if !ok {
+		return
+	}
+	switch basic.Kind() {
+	case types.Uint8:
+		cl.emit(opConvIntToByte)
+	case types.Int32:
+		cl.emit(opConvIntToRune)
+	}
+}
+
+func (cl *compiler) compileConversion(call *ast.CallExpr, dst types.Type) {
+	x := call.Args[0]
+	src := cl.ctx.Types.TypeOf(x)
+	switch {
+	case typeIsString(dst) && typeIsInt(src):
+		cl.compileExpr(x)
+		cl.emit(opConvRuneToString)
+	case typeIsFloat(dst) && typeIsInt(src):
+		cl.compileExpr(x)
+		cl.emit(opConvIntToFloat)
+	case typeIsInt(dst) && typeIsFloat(src):
+		cl.compileExpr(x)
+		cl.emit(opConvFloatToInt)
+		cl.emitIntWrap(dst)
+	case typeIsInt(dst) && typeIsInt(src):
+		cl.compileExpr(x)
+		cl.emitIntWrap(dst)
+	case types.Identical(dst.Underlying(), src.Underlying()):
+		cl.compileExpr(x)
+	case types.IsInterface(dst) && cl.isSupportedType(dst):
+		cl.compileValue(x, dst)
+	default:
+		panic(cl.errorf(call, "can't compile %s to %s conversion yet", src, dst))
+	}
+}
+
 func (cl *compiler) compileSliceExpr(slice *ast.SliceExpr) {
 	if slice.Slice3 {
 		panic(cl.errorf(slice, "can't compile 3-index slicing"))
@@ -524,15 +839,195 @@ func (cl *compiler) compileSliceExpr(slice *ast.SliceExpr) {
 	}
 }
 
+func (cl *compiler) compileIndexExpr(e *ast.IndexExpr) {
+	switch typ := cl.ctx.Types.TypeOf(e.X).Underlying().(type) {
+	case *types.Slice:
+		cl.compileExpr(e.X)
+		cl.compileExpr(e.Index)
+		cl.emitSliceIndex(typ)
+	case *types.Map:
+		cl.compileExpr(e.X)
+		cl.compileExpr(e.Index)
+		cl.emitMapIndex(typ)
+	default:
+		if !typeIsString(typ) {
+			panic(cl.errorf(e, "can't compile %s indexing yet", typ))
+		}
+		cl.compileExpr(e.X)
+		cl.compileExpr(e.Index)
+		cl.emit(opStringIndex)
+	}
+}
+
+func (cl *compiler) compileCompositeLit(lit *ast.CompositeLit) {
+	switch typ := cl.ctx.Types.TypeOf(lit).Underlying().(type) {
+	case *types.Slice:
+		cl.emit8(opPushIntConst, cl.internIntConstant(0))
+		cl.emit8(opPushIntConst, cl.internIntConstant(len(lit.Elts)))
+		cl.emitMakeSlice(typ)
+		for _, elt := range lit.Elts {
+			if _, ok := elt.(*ast.KeyValueExpr); ok {
+				panic(cl.errorf(elt, "can't compile keyed slice elements"))
+			}
+			cl.compileValue(elt, typ.Elem())
+			cl.emitAppend(typ)
+		}
+	case *types.Map:
+		cl.emit(pickOp(typeIsInt(typ.Elem()), opMakeIntMap, opMakeMap))
+		for _, elt := range lit.Elts {
+			kv := elt.(*ast.KeyValueExpr)
+			cl.emit(opDup)
+			cl.compileExpr(kv.Key)
+			cl.compileValue(kv.Value, typ.Elem())
+			cl.emit(pickOp(typeIsInt(typ.Elem()), opSetIntMapIndex, opSetMapIndex))
+		}
+	default:
+		panic(cl.errorf(lit, "can't compile %s composite literals yet", typ))
+	}
+}
+
+// compileValue compiles e that is stored as a dst-typed value:
+// a slice element, a func argument, a result and so on.
+// Int values are boxed for the non-int destinations.
+func (cl *compiler) compileValue(e ast.Expr, dst types.Type) {
+	if typeIsFunc(cl.ctx.Types.TypeOf(e)) && !typeIsFunc(dst) {
+		// Func values can't escape, so they can't be converted to interfaces.
+		panic(cl.errorf(e, "can't use func value as %s", dst))
+	}
+	cl.compileExpr(e)
+	if !typeIsInt(dst) && typeIsInt(cl.ctx.Types.TypeOf(e)) {
+		cl.emit(opConvIntToIface)
+	}
+}
+
+func (cl *compiler) compileFuncLit(lit *ast.FuncLit) {
+	captured := cl.capturedVars(lit)
+	sub := newCompiler(cl.ctx, cl.ctx.Types.TypeOf(lit).(*types.Signature))
+	sub.root = cl.root
+	cl.numFuncLits++
+	name := fmt.Sprintf("%s.func%d", cl.fnName, cl.numFuncLits)
+	compiled := sub.compileFunc(lit, name, lit.Body, captured)
+
+	// Push the captured values in the same order as they're
+	// bound to the func literal params.
+	for _, v := range captured {
+		cl.compileVarRead(lit, v.Name(), v.Type())
+	}
+	cl.emit8(opMakeClosure, cl.internConstant(compiled))
+}
+
+// capturedVars returns the enclosing func variables that are used inside lit.
+//
+// Variables are captured by value, so they can't be reassigned;
+// otherwise the closure could observe a stale value.
+func (cl *compiler) capturedVars(lit *ast.FuncLit) []*types.Var {
+	var captured []*types.Var
+	seen := make(map[*types.Var]bool)
+	ast.Inspect(lit.Body, func(n ast.Node) bool {
+		ident, ok := n.(*ast.Ident)
+		if !ok {
+			return true
+		}
+		v, ok := cl.ctx.Types.Uses[ident].(*types.Var)
+		if !ok || v.IsField() || seen[v] {
+			return true
+		}
+		if v.Pos() >= lit.Pos() && v.Pos() < lit.End() {
+			return true // Defined inside the func literal
+		}
+		_, isLocal := cl.locals[v.Name()]
+		if !isLocal && !cl.isParamName(v.Name()) {
+			return true // Not a local variable, let the func literal compiler report it
+		}
+		if isLocal && cl.isReassigned(v) {
+			panic(cl.errorf(ident, "can't capture %s: captured variables can't be reassigned", v.Name()))
+		}
+		seen[v] = true
+		captured = append(captured, v)
+		return true
+	})
+	return captured
+}
+
+// isReassigned reports whether v is assigned anywhere except its definition.
+func (cl *compiler) isReassigned(v *types.Var) bool {
+	isVar := func(e ast.Expr) bool {
+		ident, ok := e.(*ast.Ident)
+		return ok && cl.ctx.Types.Uses[ident] == v
+	}
+	reassigned := false
+	ast.Inspect(cl.root, func(n ast.Node) bool {
+		switch n := n.(type) {
+		case *ast.AssignStmt:
+			for _, lhs := range n.Lhs {
+				if isVar(lhs) {
+					reassigned = true
+				}
+			}
+		case *ast.IncDecStmt:
+			reassigned = isVar(n.X)
+		case *ast.RangeStmt:
+			if n.Tok == token.ASSIGN {
+				reassigned = (n.Key != nil && isVar(n.Key)) || (n.Value != nil && isVar(n.Value))
+			}
+		}
+		return !reassigned
+	})
+	return reassigned
+}
+
 func (cl *compiler) compileBuiltinCall(fn *ast.Ident, call *ast.CallExpr) {
 	switch fn.Name {
 	case `len`:
 		s := call.Args[0]
 		cl.compileExpr(s)
-		if !typeIsString(cl.ctx.Types.TypeOf(s)) {
-			panic(cl.errorf(s, "can't compile len() with non-string argument yet"))
+		typ := cl.ctx.Types.TypeOf(s)
+		switch typ.Underlying().(type) {
+		case *types.Slice:
+			cl.emit(opSliceLen)
+		case *types.Map:
+			cl.emit(opMapLen)
+		default:
+			if !typeIsString(typ) {
+				panic(cl.errorf(s, "can't compile len() with %s argument yet", typ))
+			}
+			cl.emit(opStringLen)
+		}
+
+	case `append`:
+		if call.Ellipsis.IsValid() {
+			panic(cl.errorf(call, "can't compile append() with ... yet"))
+		}
+		typ, ok := cl.ctx.Types.TypeOf(call.Args[0]).Underlying().(*types.Slice)
+		if !ok {
+			panic(cl.errorf(call.Args[0], "can't compile append() to a non-slice value"))
+		}
+		cl.compileExpr(call.Args[0])
+		for _, arg := range call.Args[1:] {
+			cl.compileValue(arg, typ.Elem())
+			cl.emitAppend(typ)
+		}
+
+	case `make`:
+		switch typ := cl.ctx.Types.TypeOf(call.Args[0]).Underlying().(type) {
+		case *types.Slice:
+			cl.compileExpr(call.Args[1])
+			if len(call.Args) == 3 {
+				cl.compileExpr(call.Args[2])
+			} else {
+				// The capacity can't be less than the length.
+				cl.emit8(opPushIntConst, cl.internIntConstant(0))
+			}
+			cl.emitMakeSlice(typ)
+		case *types.Map:
+			// The size hint is ignored, so it should have no side effects.
+			if len(call.Args) == 2 && cl.ctx.Types.Types[call.Args[1]].Value == nil {
+				panic(cl.errorf(call.Args[1], "map size hint should be a constant"))
+			}
+			cl.emit(pickOp(typeIsInt(typ.Elem()), opMakeIntMap, opMakeMap))
+		default:
+			panic(cl.errorf(call.Args[0], "can't compile make() for %s", typ))
 		}
-		cl.emit(opStringLen)
 
 	case `println`:
 		if len(call.Args) != 1 {
@@ -553,6 +1048,11 @@ func (cl *compiler) compileBuiltinCall(fn *ast.Ident, call *ast.CallExpr) {
 }
 
 func (cl *compiler) compileCallExpr(call *ast.CallExpr) {
+	if tv := cl.ctx.Types.Types[call.Fun]; tv.IsType() {
+		cl.compileConversion(call, tv.Type)
+		return
+	}
+
 	if id, ok := astutil.Unparen(call.Fun).(*ast.Ident); ok {
 		_, isBuiltin := cl.ctx.Types.ObjectOf(id).(*types.Builtin)
 		if isBuiltin {
@@ -561,6 +1061,11 @@ func (cl *compiler) compileCallExpr(call *ast.CallExpr) {
 		}
 	}
 
+	if cl.isFuncValueCall(call) {
+		cl.compileClosureCall(call)
+		return
+	}
+
 	expr, fn := goutil.ResolveFunc(cl.ctx.Types, call.Fun)
 	if fn == nil {
 		panic(cl.errorf(call.Fun, "can't resolve the called function"))
@@ -584,38 +1089,65 @@ func (cl *compiler) compileCallExpr(call *ast.CallExpr) {
 	if cl.compileNativeCall(key, variadic, expr, call.Args) {
 		return
 	}
-	if cl.compileCall(key, sig, call.Args) {
+	if cl.compileCall(key, fn, sig, call.Args) {
 		return
 	}
 	panic(cl.errorf(call.Fun, "can't compile a call to %s func", key))
 }
 
-func (cl *compiler) compileCall(key funcKey, sig *types.Signature, args []ast.Expr) bool {
+func (cl *compiler) compileCall(key funcKey, fn *types.Func, sig *types.Signature, args []ast.Expr) bool {
 	if sig.Variadic() {
 		return false
 	}
 
+	// The func is not bound to the env until it's compiled,
+	// so the recursive calls are compiled to special opcodes.
+	recursive := cl.fnObj != nil && fn.Origin() == cl.fnObj
 	funcID, ok := cl.ctx.Env.nameToFuncID[key]
-	if !ok {
+	if !ok && !recursive {
 		return false
 	}
 
-	for _, arg := range args {
-		cl.compileExpr(arg)
+	cl.compileArgs(sig, args)
+
+	if recursive {
+		cl.emit(pickCallOp(sig, opCallRecur, opIntCallRecur, opVoidCallRecur, opMultiCallRecur))
+		return true
 	}
+	cl.emit16(pickCallOp(sig, opCall, opIntCall, opVoidCall, opMultiCall), int(funcID))
+	return true
+}
 
-	var op opcode
-	switch {
-	case sig.Results().Len() == 0:
-		op = opVoidCall
-	case typeIsInt(sig.Results().At(0).Type()):
-		op = opIntCall
+// isFuncValueCall reports whether call invokes a func literal or a func-typed variable.
+func (cl *compiler) isFuncValueCall(call *ast.CallExpr) bool {
+	switch fn := astutil.Unparen(call.Fun).(type) {
+	case *ast.FuncLit:
+		return true
+	case *ast.Ident:
+		_, ok := cl.ctx.Types.ObjectOf(fn).(*types.Var)
+		return ok
 	default:
-		op = opCall
+		return false
 	}
+}
 
-	cl.emit16(op, int(funcID))
-	return true
+func (cl *compiler) compileClosureCall(call *ast.CallExpr) {
+	sig := cl.ctx.Types.TypeOf(call.Fun).Underlying().(*types.Signature)
+	if sig.Variadic() {
+		panic(cl.errorf(call.Fun, "can't compile variadic func value calls yet"))
+	}
+	cl.compileArgs(sig, call.Args)
+	cl.compileExpr(call.Fun)
+	cl.emit(pickCallOp(sig, opCallClosure, opIntCallClosure, opVoidCallClosure, opMultiCallClosure))
+}
+
+func (cl *compiler) compileArgs(sig *types.Signature, args []ast.Expr) {
+	if len(args) == 1 && sig.Params().Len() > 1 {
+		panic(cl.errorf(args[0], "can't pass tuple as a func argument"))
+	}
+	for i, arg := range args {
+		cl.compileValue(arg, sig.Params().At(i).Type())
+	}
 }
 
 func (cl *compiler) compileNativeCall(key funcKey, variadic int, funcExpr ast.Expr, args []ast.Expr) bool {
@@ -628,8 +1160,8 @@ func (cl *compiler) compileNativeCall(key funcKey, variadic int, funcExpr ast.Ex
 		// Check that it's not a f(g()) call, where g() returns
 		// a multi-value result; we can't compile that yet.
 		if call, ok := args[0].(*ast.CallExpr); ok {
-			results := cl.ctx.Types.TypeOf(call.Fun).(*types.Signature).Results()
-			if results != nil && results.Len() > 1 {
+			// Only the multi-value calls have a tuple type.
+			if results, ok := cl.ctx.Types.TypeOf(call).(*types.Tuple); ok && results.Len() > 1 {
 				panic(cl.errorf(args[0], "can't pass tuple as a func argument"))
 			}
 		}
@@ -642,6 +1174,12 @@ func (cl *compiler) compileNativeCall(key funcKey, variadic int, funcExpr ast.Ex
 		variadicArgs = args[variadic:]
 	}
 
+	for _, arg := range args {
+		if typeIsFunc(cl.ctx.Types.TypeOf(arg)) {
+			panic(cl.errorf(arg, "can't pass func value to a native func"))
+		}
+	}
+
 	for _, arg := range normalArgs {
 		cl.compileExpr(arg)
 	}
@@ -703,23 +1241,34 @@ func (cl *compiler) compileIdent(ident *ast.Ident) {
 		cl.compileConstantValue(ident, cv)
 		return
 	}
-	if paramIndex, ok := cl.params[ident.String()]; ok {
+	cl.compileVarRead(ident, ident.String(), tv.Type)
+}
+
+func (cl *compiler) compileVarRead(n ast.Node, varname string, typ types.Type) {
+	if paramIndex, ok := cl.params[varname]; ok {
 		cl.emit8(opPushParam, paramIndex)
 		return
 	}
-	if paramIndex, ok := cl.intParams[ident.String()]; ok {
+	if paramIndex, ok := cl.intParams[varname]; ok {
 		cl.emit8(opPushIntParam, paramIndex)
 		return
 	}
-	if localIndex, ok := cl.locals[ident.String()]; ok {
-		cl.emit8(pickOp(typeIsInt(tv.Type), opPushIntLocal, opPushLocal), localIndex)
+	if localIndex, ok := cl.locals[varname]; ok {
+		cl.emit8(pickOp(typeIsInt(typ), opPushIntLocal, opPushLocal), localIndex)
 		return
 	}
 
-	panic(cl.errorf(ident, "can't compile a %s (type %s) variable read", ident.String(), tv.Type))
+	panic(cl.errorf(n, "can't compile a %s (type %s) variable read", varname, typ))
 }
 
 func (cl *compiler) compileConstantValue(source ast.Expr, cv constant.Value) {
+	if typeIsFloat(cl.ctx.Types.TypeOf(source)) {
+		// Float-typed constants can have an int kind, like 1 in `x > 1`.
+		v, _ := constant.Float64Val(constant.ToFloat(cv))
+		cl.emit8(opPushConst, cl.internConstant(v))
+		return
+	}
+
 	switch cv.Kind() {
 	case constant.Bool:
 		v := constant.BoolVal(cv)
@@ -746,13 +1295,69 @@ func (cl *compiler) compileConstantValue(source ast.Expr, cv constant.Value) {
 		panic(cl.errorf(source, "can't compile complex number constants yet"))
 
 	case constant.Float:
-		panic(cl.errorf(source, "can't compile float constants yet"))
+		panic(cl.errorf(source, "can't compile %s float constant", cl.ctx.Types.TypeOf(source)))
 
 	default:
 		panic(cl.errorf(source, "unexpected constant %v", cv))
 	}
 }
 
+func (cl *compiler) emitMakeSlice(typ *types.Slice) {
+	switch elem := typ.Elem(); {
+	case typeIsInt(elem):
+		cl.emit(opMakeIntSlice)
+	case typeIsString(elem):
+		cl.emit(opMakeStringSlice)
+	default:
+		cl.emit8(opMakeSlice, cl.internZeroValue(elem))
+	}
+}
+
+func (cl *compiler) emitSliceIndex(typ *types.Slice) {
+	switch elem := typ.Elem(); {
+	case typeIsInt(elem):
+		cl.emit(opIntSliceIndex)
+	case typeIsString(elem):
+		cl.emit(opStringSliceIndex)
+	default:
+		cl.emit(opSliceIndex)
+	}
+}
+
+func (cl *compiler) emitAppend(typ *types.Slice) {
+	switch elem := typ.Elem(); {
+	case typeIsInt(elem):
+		cl.emit(opIntAppend)
+	case typeIsString(elem):
+		cl.emit(opStringAppend)
+	default:
+		cl.emit(opAppend)
+	}
+}
+
+func (cl *compiler) emitMapIndex(typ *types.Map) {
+	if typeIsInt(typ.Elem()) {
+		cl.emit(opIntMapIndex)
+		return
+	}
+	cl.emit8(opMapIndex, cl.internZeroValue(typ.Elem()))
+}
+
+// internZeroValue returns a constant id of the typ zero value.
+// For slices, maps, pointers and interfaces it's nil.
+func (cl *compiler) internZeroValue(typ types.Type) int {
+	var zero interface{}
+	switch {
+	case typeIsString(typ):
+		zero = ""
+	case typeIsBool(typ):
+		zero = false
+	case typeIsFloat(typ):
+		zero = 0.0
+	}
+	return cl.internConstant(zero)
+}
+
 func (cl *compiler) internIntConstant(v int) int {
 	if id, ok := cl.intConstantsPool[v]; ok {
 		return id
@@ -831,7 +1436,7 @@ func (cl *compiler) errorf(n ast.Node, format string, args ...interface{}) compi
 
 func (cl *compiler) isUncondJump(op opcode) bool {
 	switch op {
-	case opJump, opReturnFalse, opReturnTrue, opReturnTop, opReturnIntTop:
+	case opJump, opReturnFalse, opReturnTrue, opReturnTop, opReturnIntTop, opReturnMulti:
 		return true
 	default:
 		return false
@@ -851,9 +1456,9 @@ func (cl *compiler) isSupportedType(typ types.Type) bool {
 
 	case *types.Basic:
 		// 2. Some of the basic types are supported.
-		// TODO: support byte/uint8 and maybe float64.
+		// Bytes and runes are stored as ints, floats are boxed.
 		switch typ.Kind() {
-		case types.Bool, types.Int, types.String:
+		case types.Bool, types.Int, types.String, types.Uint8, types.Int32, types.Float64:
 			return true
 		default:
 			return false
@@ -863,6 +1468,14 @@ func (cl *compiler) isSupportedType(typ types.Type) bool {
 		// 3. Interfaces are supported.
 		return true
 
+	case *types.Slice:
+		// 4. Slices of the supported types.
+		return cl.isSupportedType(typ.Elem())
+
+	case *types.Map:
+		// 5. Maps with string keys and the supported value types.
+		return typeIsString(typ.Key()) && cl.isSupportedType(typ.Elem())
+
 	default:
 		return false
 	}
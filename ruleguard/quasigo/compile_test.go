@@ -373,6 +373,25 @@ func TestCompile(t *testing.T) {
 			`  Call 1 # testpkg.concat`,
 			`  ReturnTop`,
 		},
+
+		`add := func(x int) int { return x + i }; return add(1)`: {
+			`  PushIntParam 0 # i`,
+			`  MakeClosure 0 # testpkg.f.func1`,
+			`  SetLocal 0 # add`,
+			`  PushIntConst 0 # value=1`,
+			`  PushLocal 0 # add`,
+			`  IntCallClosure`,
+			`  ReturnIntTop`,
+		},
+
+		`x, _ := atoi(s); return x`: {
+			`  PushParam 0 # s`,
+			`  CallNative 2 # testpkg.atoi`,
+			`  Pop`,
+			`  SetIntLocal 0 # x`,
+			`  PushIntLocal 0 # x`,
+			`  ReturnIntTop`,
+		},
 	}
 
 	makePackageSource := func(body string) string {
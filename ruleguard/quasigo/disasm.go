@@ -39,7 +39,7 @@ func disasm(env *Env, fn *Func) string {
 			id := decode16(code, pc+1)
 			arg = id
 			comment = env.nativeFuncs[id].name
-		case opCall, opIntCall, opVoidCall:
+		case opCall, opIntCall, opVoidCall, opMultiCall:
 			id := decode16(code, pc+1)
 			arg = id
 			comment = env.userFuncs[id].name
@@ -57,9 +57,12 @@ func disasm(env *Env, fn *Func) string {
 			comment = dbg.localNames[index]
 		case opSetVariadicLen:
 			arg = int(code[pc+1])
-		case opPushConst:
+		case opPushConst, opMakeSlice, opMapIndex:
 			arg = int(code[pc+1])
 			comment = fmt.Sprintf("value=%#v", fn.constants[code[pc+1]])
+		case opMakeClosure:
+			arg = int(code[pc+1])
+			comment = fn.constants[code[pc+1]].(*Func).name
 		case opPushIntConst:
 			arg = int(code[pc+1])
 			comment = fmt.Sprintf("value=%#v", fn.intConstants[code[pc+1]])
@@ -3,9 +3,24 @@ package quasigo
 import (
 	"fmt"
 	"reflect"
+	"unicode/utf8"
 )
 
-const maxFuncLocals = 8
+const maxFuncLocals = 16
+
+// maxCallDepth limits the user func calls nesting.
+// Without it, a runaway recursion would crash the
+// whole program with a Go stack overflow.
+const maxCallDepth = 256
+
+// closure is a runtime representation of a func literal value.
+// The captured values are passed to the closure body as extra params.
+type closure struct {
+	fn *Func
+
+	captured    []interface{}
+	intCaptured []int
+}
 
 // pop2 removes the two top stack elements and returns them.
 //
@@ -18,6 +33,12 @@ func (s *ValueStack) pop2() (second, top interface{}) {
 	return x, y
 }
 
+// popFloat2 is like pop2, but it also unboxes the float64 values.
+func (s *ValueStack) popFloat2() (second, top float64) {
+	x, y := s.pop2()
+	return x.(float64), y.(float64)
+}
+
 func (s *ValueStack) popInt2() (second, top int) {
 	x := s.ints[len(s.ints)-2]
 	y := s.ints[len(s.ints)-1]
@@ -38,6 +59,39 @@ func (s *ValueStack) dup() { s.objects = append(s.objects, s.objects[len(s.objec
 // Identical to s.Pop() without using the result.
 func (s *ValueStack) discard() { s.objects = s.objects[:len(s.objects)-1] }
 
+// evalCall invokes a user func with the args that are on top of the stack.
+// The args are popped off the stack after the call.
+//
+// The single result is returned as CallResult, while the
+// multi-value results are left on the stack in place of the args.
+func evalCall(env *EvalEnv, fn *Func) CallResult {
+	stack := &env.Stack
+	top := len(stack.objects) - fn.numObjectParams
+	intTop := len(stack.ints) - fn.numIntParams
+	if env.callDepth == maxCallDepth {
+		panic(&CallDepthError{FuncName: fn.name})
+	}
+	env.callDepth++
+	result := eval(env, fn, top, intTop)
+	env.callDepth--
+	if fn.numObjectResults != 0 {
+		copy(stack.objects[top:], stack.objects[len(stack.objects)-fn.numObjectResults:])
+	}
+	if fn.numIntResults != 0 {
+		copy(stack.ints[intTop:], stack.ints[len(stack.ints)-fn.numIntResults:])
+	}
+	stack.objects = stack.objects[:top+fn.numObjectResults]
+	stack.ints = stack.ints[:intTop+fn.numIntResults]
+	return result
+}
+
+// evalClosureCall is like evalCall, but it also binds the captured values.
+func evalClosureCall(env *EvalEnv, c *closure) CallResult {
+	env.Stack.objects = append(env.Stack.objects, c.captured...)
+	env.Stack.ints = append(env.Stack.ints, c.intCaptured...)
+	return evalCall(env, c.fn)
+}
+
 func eval(env *EvalEnv, fn *Func, top, intTop int) CallResult {
 	pc := 0
 	code := fn.code
@@ -86,6 +140,9 @@ func eval(env *EvalEnv, fn *Func, top, intTop int) CallResult {
 		case opPop:
 			stack.discard()
 			pc++
+		case opPopInt:
+			stack.ints = stack.ints[:len(stack.ints)-1]
+			pc++
 		case opDup:
 			stack.dup()
 			pc++
@@ -102,6 +159,21 @@ func eval(env *EvalEnv, fn *Func, top, intTop int) CallResult {
 		case opConvIntToIface:
 			stack.Push(stack.PopInt())
 			pc++
+		case opConvIntToByte:
+			stack.ints[len(stack.ints)-1] = int(uint8(stack.topInt()))
+			pc++
+		case opConvIntToRune:
+			stack.ints[len(stack.ints)-1] = int(int32(stack.topInt()))
+			pc++
+		case opConvIntToFloat:
+			stack.Push(float64(stack.PopInt()))
+			pc++
+		case opConvFloatToInt:
+			stack.PushInt(int(stack.Pop().(float64)))
+			pc++
+		case opConvRuneToString:
+			stack.Push(string(rune(stack.PopInt())))
+			pc++
 
 		case opPushTrue:
 			stack.Push(true)
@@ -120,6 +192,9 @@ func eval(env *EvalEnv, fn *Func, top, intTop int) CallResult {
 			return CallResult{scalarValue: uint64(stack.topInt())}
 		case opReturn:
 			return CallResult{}
+		case opReturnMulti:
+			// The results are already on the stack.
+			return CallResult{}
 
 		case opSetVariadicLen:
 			stack.variadicLen = int(code[pc+1])
@@ -131,22 +206,58 @@ func eval(env *EvalEnv, fn *Func, top, intTop int) CallResult {
 			pc += 3
 		case opCall:
 			id := decode16(code, pc+1)
-			fn := env.userFuncs[id]
-			result := eval(env, fn, len(stack.objects)-fn.numObjectParams, len(stack.ints)-fn.numIntParams)
+			result := evalCall(env, env.userFuncs[id])
 			stack.Push(result.Value())
 			pc += 3
 		case opIntCall:
 			id := decode16(code, pc+1)
-			fn := env.userFuncs[id]
-			result := eval(env, fn, len(stack.objects)-fn.numObjectParams, len(stack.ints)-fn.numIntParams)
+			result := evalCall(env, env.userFuncs[id])
 			stack.PushInt(result.IntValue())
 			pc += 3
-		case opVoidCall:
+		case opVoidCall, opMultiCall:
 			id := decode16(code, pc+1)
-			fn := env.userFuncs[id]
-			eval(env, fn, len(stack.objects)-fn.numObjectParams, len(stack.ints)-fn.numIntParams)
+			evalCall(env, env.userFuncs[id])
 			pc += 3
 
+		case opCallRecur:
+			result := evalCall(env, fn)
+			stack.Push(result.Value())
+			pc++
+		case opIntCallRecur:
+			result := evalCall(env, fn)
+			stack.PushInt(result.IntValue())
+			pc++
+		case opVoidCallRecur, opMultiCallRecur:
+			evalCall(env, fn)
+			pc++
+
+		case opMakeClosure:
+			f := fn.constants[code[pc+1]].(*Func)
+			c := &closure{fn: f}
+			if n := f.numCaptured; n != 0 {
+				from := len(stack.objects) - n
+				c.captured = append([]interface{}(nil), stack.objects[from:]...)
+				stack.objects = stack.objects[:from]
+			}
+			if n := f.numIntCaptured; n != 0 {
+				from := len(stack.ints) - n
+				c.intCaptured = append([]int(nil), stack.ints[from:]...)
+				stack.ints = stack.ints[:from]
+			}
+			stack.Push(c)
+			pc += 2
+		case opCallClosure:
+			result := evalClosureCall(env, stack.Pop().(*closure))
+			stack.Push(result.Value())
+			pc++
+		case opIntCallClosure:
+			result := evalClosureCall(env, stack.Pop().(*closure))
+			stack.PushInt(result.IntValue())
+			pc++
+		case opVoidCallClosure, opMultiCallClosure:
+			evalClosureCall(env, stack.Pop().(*closure))
+			pc++
+
 		case opJump:
 			offset := decode16(code, pc+1)
 			pc += offset
@@ -185,6 +296,34 @@ func eval(env *EvalEnv, fn *Func, top, intTop int) CallResult {
 			stack.PushInt(x - y)
 			pc++
 
+		case opMul:
+			x, y := stack.popInt2()
+			stack.PushInt(x * y)
+			pc++
+
+		case opDiv:
+			// Like in Go, the division by zero panics with a runtime error.
+			x, y := stack.popInt2()
+			stack.PushInt(x / y)
+			pc++
+
+		case opAddFloat:
+			x, y := stack.popFloat2()
+			stack.Push(x + y)
+			pc++
+		case opSubFloat:
+			x, y := stack.popFloat2()
+			stack.Push(x - y)
+			pc++
+		case opMulFloat:
+			x, y := stack.popFloat2()
+			stack.Push(x * y)
+			pc++
+		case opDivFloat:
+			x, y := stack.popFloat2()
+			stack.Push(x / y)
+			pc++
+
 		case opEqInt:
 			x, y := stack.popInt2()
 			stack.Push(x == y)
@@ -215,6 +354,31 @@ func eval(env *EvalEnv, fn *Func, top, intTop int) CallResult {
 			stack.Push(x <= y)
 			pc++
 
+		case opEqFloat:
+			x, y := stack.popFloat2()
+			stack.Push(x == y)
+			pc++
+		case opNotEqFloat:
+			x, y := stack.popFloat2()
+			stack.Push(x != y)
+			pc++
+		case opGtFloat:
+			x, y := stack.popFloat2()
+			stack.Push(x > y)
+			pc++
+		case opGtEqFloat:
+			x, y := stack.popFloat2()
+			stack.Push(x >= y)
+			pc++
+		case opLtFloat:
+			x, y := stack.popFloat2()
+			stack.Push(x < y)
+			pc++
+		case opLtEqFloat:
+			x, y := stack.popFloat2()
+			stack.Push(x <= y)
+			pc++
+
 		case opEqString:
 			x, y := stack.pop2()
 			stack.Push(x.(string) == y.(string))
@@ -227,12 +391,12 @@ func eval(env *EvalEnv, fn *Func, top, intTop int) CallResult {
 
 		case opIsNil:
 			x := stack.Pop()
-			stack.Push(x == nil || reflect.ValueOf(x).IsNil())
+			stack.Push(isNil(x))
 			pc++
 
 		case opIsNotNil:
 			x := stack.Pop()
-			stack.Push(x != nil && !reflect.ValueOf(x).IsNil())
+			stack.Push(!isNil(x))
 			pc++
 
 		case opStringSlice:
@@ -257,9 +421,140 @@ func eval(env *EvalEnv, fn *Func, top, intTop int) CallResult {
 		case opStringLen:
 			stack.PushInt(len(stack.Pop().(string)))
 			pc++
+		case opStringIndex:
+			index := stack.PopInt()
+			s := stack.Pop().(string)
+			stack.PushInt(int(s[index]))
+			pc++
+
+		case opStringDecodeRune:
+			index := stack.PopInt()
+			s := stack.Pop().(string)
+			ch, size := utf8.DecodeRuneInString(s[index:])
+			stack.PushInt(int(ch))
+			stack.PushInt(size)
+			pc++
+
+		case opMakeSlice:
+			length, capacity := stack.popInt2()
+			zero := fn.constants[code[pc+1]]
+			slice := make([]interface{}, length, maxInt(length, capacity))
+			if zero != nil {
+				for i := range slice {
+					slice[i] = zero
+				}
+			}
+			stack.Push(slice)
+			pc += 2
+		case opMakeStringSlice:
+			length, capacity := stack.popInt2()
+			stack.Push(make([]string, length, maxInt(length, capacity)))
+			pc++
+		case opMakeIntSlice:
+			length, capacity := stack.popInt2()
+			stack.Push(make([]int, length, maxInt(length, capacity)))
+			pc++
+
+		case opSliceLen:
+			stack.PushInt(sliceLen(stack.Pop()))
+			pc++
+
+		case opSliceIndex:
+			index := stack.PopInt()
+			stack.Push(stack.Pop().([]interface{})[index])
+			pc++
+		case opStringSliceIndex:
+			index := stack.PopInt()
+			stack.Push(stack.Pop().([]string)[index])
+			pc++
+		case opIntSliceIndex:
+			index := stack.PopInt()
+			stack.PushInt(stack.Pop().([]int)[index])
+			pc++
+
+		case opAppend:
+			// A nil slice can come from the map index zero value.
+			x, y := stack.pop2()
+			slice, _ := x.([]interface{})
+			stack.Push(append(slice, y))
+			pc++
+		case opStringAppend:
+			x, y := stack.pop2()
+			slice, _ := x.([]string)
+			stack.Push(append(slice, y.(string)))
+			pc++
+		case opIntAppend:
+			slice, _ := stack.Pop().([]int)
+			stack.Push(append(slice, stack.PopInt()))
+			pc++
+
+		case opMakeMap:
+			stack.Push(make(map[string]interface{}))
+			pc++
+		case opMakeIntMap:
+			stack.Push(make(map[string]int))
+			pc++
+
+		case opMapLen:
+			switch m := stack.Pop().(type) {
+			case map[string]interface{}:
+				stack.PushInt(len(m))
+			case map[string]int:
+				stack.PushInt(len(m))
+			default:
+				stack.PushInt(0)
+			}
+			pc++
+
+		case opMapIndex:
+			key := stack.Pop().(string)
+			m, _ := stack.Pop().(map[string]interface{})
+			v, ok := m[key]
+			if !ok {
+				v = fn.constants[code[pc+1]]
+			}
+			stack.Push(v)
+			pc += 2
+		case opIntMapIndex:
+			key := stack.Pop().(string)
+			m, _ := stack.Pop().(map[string]int)
+			stack.PushInt(m[key])
+			pc++
+
+		case opSetMapIndex:
+			value := stack.Pop()
+			key := stack.Pop().(string)
+			m := stack.Pop().(map[string]interface{})
+			m[key] = value
+			pc++
+		case opSetIntMapIndex:
+			value := stack.PopInt()
+			key := stack.Pop().(string)
+			m := stack.Pop().(map[string]int)
+			m[key] = value
+			pc++
+
+		case opMapKeys:
+			stack.Push(mapKeys(stack.Pop()))
+			pc++
 
 		default:
 			panic(fmt.Sprintf("malformed bytecode: unexpected %s found", op))
 		}
 	}
 }
+
+// isNil reports whether x is nil or a nil-valued pointer, map, slice and so on.
+// Non-nillable values, like a go/constant int64 value, are never nil.
+func isNil(x interface{}) bool {
+	if x == nil {
+		return true
+	}
+	v := reflect.ValueOf(x)
+	switch v.Kind() {
+	case reflect.Ptr, reflect.Map, reflect.Slice, reflect.Func, reflect.Interface, reflect.Chan, reflect.UnsafePointer:
+		return v.IsNil()
+	default:
+		return false
+	}
+}
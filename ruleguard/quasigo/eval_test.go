@@ -12,9 +12,14 @@ import (
 	"github.com/google/go-cmp/cmp"
 	"github.com/quasilyte/go-ruleguard/ruleguard/quasigo"
 	"github.com/quasilyte/go-ruleguard/ruleguard/quasigo/internal/evaltest"
+	"github.com/quasilyte/go-ruleguard/ruleguard/quasigo/stdlib/qfilepath"
 	"github.com/quasilyte/go-ruleguard/ruleguard/quasigo/stdlib/qfmt"
+	"github.com/quasilyte/go-ruleguard/ruleguard/quasigo/stdlib/qpath"
+	"github.com/quasilyte/go-ruleguard/ruleguard/quasigo/stdlib/qregexp"
+	"github.com/quasilyte/go-ruleguard/ruleguard/quasigo/stdlib/qsort"
 	"github.com/quasilyte/go-ruleguard/ruleguard/quasigo/stdlib/qstrconv"
 	"github.com/quasilyte/go-ruleguard/ruleguard/quasigo/stdlib/qstrings"
+	"github.com/quasilyte/go-ruleguard/ruleguard/quasigo/stdlib/qunicode"
 )
 
 func TestEval(t *testing.T) {
@@ -39,6 +44,14 @@ func TestEval(t *testing.T) {
 		{`i + i`, 20},
 		{`i - 5`, 5},
 		{`5 - i`, -5},
+		{`i * 3`, 30},
+		{`i * i * -2`, -200},
+		{`i / 3`, 3},
+		{`(0 - i) / 4`, -2},
+		{`i * 5 / 2`, 25},
+		{`int(byte(i) * 30)`, 44},
+		{`int(byte(i+245) / 2)`, 127},
+		{`int(rune(i) * 'a')`, 970},
 
 		// String operators.
 		{`s + s`, "foofoo"},
@@ -115,6 +128,15 @@ func TestEval(t *testing.T) {
 		{`len(s)`, 3},
 		{`len(s) == 3`, true},
 		{`len(s[1:])`, 2},
+
+		{`s[0] == 'f'`, true},
+		{`int(s[1])`, int('o')},
+		{`string(s[1])`, "o"},
+		{`string(rune(i + 'a'))`, "k"},
+		{`int(byte(i + 250))`, 4},
+		{`int(float64(i) / 4)`, 2},
+		{`float64(i) > 9.5`, true},
+		{`float64(i)*0.5 == 5`, true},
 	}
 
 	tests := []testCase{
@@ -148,6 +170,36 @@ func TestEval(t *testing.T) {
 		{`j := -5; for j <= 0 { j++; }; return j`, 1},
 		{`j := 0; for j < 100 { k := 0; for { if k > 40 { break }; k++; j++; } }; return j`, 123},
 		{`j := 0; for j < 10000 { k := 0; for k < 10 { k++; j++; } }; return j`, 10000},
+
+		{`xs := []int{1, 2}; return len(xs)`, 2},
+		{`xs := []int{1, 2}; return xs[1]`, 2},
+		{`xs := []string{"a", s}; return xs[1]`, "foo"},
+		{`xs := []bool{false}; xs = append(xs, b); return xs[1]`, true},
+		{`xs := []int{}; xs = append(xs, i, 5); return xs[0] + xs[1]`, 15},
+		{`xs := make([]string, 2); return xs[0] == ""`, true},
+		{`xs := make([]int, 2, 10); xs = append(xs, 7); return len(xs)`, 3},
+		{`xs := []interface{}{1, "a"}; return len(xs)`, 2},
+		{`xs := [][]int{{1}, {2, 3}}; return len(xs[1])`, 2},
+		{`m := map[string]int{"a": 1}; m["b"] = 2; return m["a"] + m["b"] + m["c"]`, 3},
+		{`m := make(map[string]string); m[s] = "x"; return m["foo"] + m["bar"]`, "x"},
+		{`m := map[string]bool{}; return m["x"]`, false},
+		{`m := map[string]int{}; return len(m) + m["x"]`, 0},
+		{`m := map[string][]string{}; m["a"] = []string{"b"}; return len(m["a"]) + len(m["b"])`, 1},
+		{`j := 0; for _, x := range []int{1, 2, 3} { j = j + x }; return j`, 6},
+		{`j := 0; for k := range []int{1, 2, 3} { j = j + k }; return j`, 3},
+		{`j := 0; for range []string{"a", "b"} { j++ }; return j`, 2},
+		{`j := 0; for _, x := range []int{1, 2, 3, 4} { if x == 2 { continue }; if x == 4 { break }; j = j + x }; return j`, 4},
+		{`j := 0; for k := range "x€y" { j = j + k }; return j`, 5},
+		{`j := 0; for range s { j++ }; return j`, 3},
+		{`out := ""; for k, v := range map[string]string{"b": "2", "a": "1"} { out = out + k + v }; return out`, "a1b2"},
+		{`j := 0; for _, v := range map[string]int{"a": 1, "b": 2} { j = j + v }; return j`, 3},
+		{`k := ""; for k = range map[string]int{"a": 1, "b": 2} {}; return k`, "b"},
+
+		{`f := func(x int) int { return x + i }; return f(1)`, 11},
+		{`return func() string { return s + "!" }()`, "foo!"},
+		{`pred := func(x string) bool { return x == s }; return pred("foo") && !pred("bar")`, true},
+		{`f := func() (int, string) { return i, s }; x, y := f(); return y + y[:x-9]`, "foof"},
+		{`n := 0; add := func(x int) int { return x + i }; for n < 100 { n = add(n) }; return n`, 100},
 	}
 
 	for _, test := range exprTests {
@@ -281,6 +333,11 @@ func TestEvalFile(t *testing.T) {
 		qstrings.ImportAll(env)
 		qstrconv.ImportAll(env)
 		qfmt.ImportAll(env)
+		qunicode.ImportAll(env)
+		qregexp.ImportAll(env)
+		qpath.ImportAll(env)
+		qfilepath.ImportAll(env)
+		qsort.ImportAll(env)
 
 		mainFunc, err := compileTestFile(env, "main", "main", parsed)
 		if err != nil {
@@ -318,3 +375,58 @@ func TestEvalFile(t *testing.T) {
 		})
 	}
 }
+
+func TestEvalCallDepth(t *testing.T) {
+	const src = `
+	  package ` + testPackage + `
+	  func infinite(n int) int { return infinite(n + 1) }
+	  func countdown(n int) int {
+	    if n == 0 { return 0 }
+	    return countdown(n - 1)
+	  }
+	  func target() int { return infinite(0) }
+	  func target2() int { return countdown(200) }
+	`
+	parsed, err := parseGoFile(testPackage, src)
+	if err != nil {
+		t.Fatal(err)
+	}
+	env := quasigo.NewEnv()
+	target, err := compileTestFile(env, "target", testPackage, parsed)
+	if err != nil {
+		t.Fatal(err)
+	}
+	target2, err := compileTestFile(env, "target2", testPackage, parsed)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	evalEnv := env.GetEvalEnv()
+	evalEnv.Stack.Push("sentinel")
+	callRecover := func(fn *quasigo.Func) (rv interface{}) {
+		defer func() {
+			rv = recover()
+		}()
+		quasigo.Call(evalEnv, fn)
+		return nil
+	}
+
+	rv := callRecover(target)
+	depthErr, ok := rv.(*quasigo.CallDepthError)
+	if !ok {
+		t.Fatalf("expected a *CallDepthError panic, have %#v", rv)
+	}
+	want := "quasigo: " + testPackage + ".infinite: max call depth (256) exceeded"
+	if depthErr.Error() != want {
+		t.Fatalf("unexpected error:\nhave: %s\nwant: %s", depthErr.Error(), want)
+	}
+
+	// The env state should be restored after the panic,
+	// so the deep (but limited) recursion still works.
+	if rv := callRecover(target2); rv != nil {
+		t.Fatalf("unexpected panic after the env reuse: %v", rv)
+	}
+	if v := evalEnv.Stack.Pop(); v != "sentinel" {
+		t.Fatalf("the stack is not restored, top value is %v", v)
+	}
+}
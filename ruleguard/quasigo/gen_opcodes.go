@@ -15,6 +15,7 @@ import (
 
 var opcodePrototypes = []opcodeProto{
 	{"Pop", "op", "(value) -> ()"},
+	{"PopInt", "op", "(value:int) -> ()"},
 	{"Dup", "op", "(x) -> (x x)"},
 
 	{"PushParam", "op index:u8", "() -> (value)"},
@@ -27,6 +28,11 @@ var opcodePrototypes = []opcodeProto{
 	{"PushIntConst", "op constid:u8", "() -> (const:int)"},
 
 	{"ConvIntToIface", "op", "(value:int) -> (value)"},
+	{"ConvIntToByte", "op", "(value:int) -> (result:int)"},
+	{"ConvIntToRune", "op", "(value:int) -> (result:int)"},
+	{"ConvIntToFloat", "op", "(value:int) -> (result:float64)"},
+	{"ConvFloatToInt", "op", "(value:float64) -> (result:int)"},
+	{"ConvRuneToString", "op", "(value:int) -> (result:string)"},
 
 	{"SetLocal", "op index:u8", "(value) -> ()"},
 	{"SetIntLocal", "op index:u8", "(value:int) -> ()"},
@@ -38,6 +44,7 @@ var opcodePrototypes = []opcodeProto{
 	{"ReturnFalse", "op", stackUnchanged},
 	{"ReturnTrue", "op", stackUnchanged},
 	{"Return", "op", stackUnchanged},
+	{"ReturnMulti", "op", "(results...) -> (results...)"},
 
 	{"Jump", "op offset:i16", stackUnchanged},
 	{"JumpFalse", "op offset:i16", "(cond:bool) -> ()"},
@@ -48,6 +55,16 @@ var opcodePrototypes = []opcodeProto{
 	{"Call", "op funcid:u16", "(args...) -> (result)"},
 	{"IntCall", "op funcid:u16", "(args...) -> (result:int)"},
 	{"VoidCall", "op funcid:u16", "(args...) -> ()"},
+	{"MultiCall", "op funcid:u16", "(args...) -> (results...)"},
+	{"CallRecur", "op", "(args...) -> (result)"},
+	{"IntCallRecur", "op", "(args...) -> (result:int)"},
+	{"VoidCallRecur", "op", "(args...) -> ()"},
+	{"MultiCallRecur", "op", "(args...) -> (results...)"},
+	{"MakeClosure", "op constid:u8", "(captured...) -> (closure)"},
+	{"CallClosure", "op", "(args... closure) -> (result)"},
+	{"IntCallClosure", "op", "(args... closure) -> (result:int)"},
+	{"VoidCallClosure", "op", "(args... closure) -> ()"},
+	{"MultiCallClosure", "op", "(args... closure) -> (results...)"},
 
 	{"IsNil", "op", "(value) -> (result:bool)"},
 	{"IsNotNil", "op", "(value) -> (result:bool)"},
@@ -61,17 +78,52 @@ var opcodePrototypes = []opcodeProto{
 	{"LtInt", "op", "(x:int y:int) -> (result:bool)"},
 	{"LtEqInt", "op", "(x:int y:int) -> (result:bool)"},
 
+	{"EqFloat", "op", "(x:float64 y:float64) -> (result:bool)"},
+	{"NotEqFloat", "op", "(x:float64 y:float64) -> (result:bool)"},
+	{"GtFloat", "op", "(x:float64 y:float64) -> (result:bool)"},
+	{"GtEqFloat", "op", "(x:float64 y:float64) -> (result:bool)"},
+	{"LtFloat", "op", "(x:float64 y:float64) -> (result:bool)"},
+	{"LtEqFloat", "op", "(x:float64 y:float64) -> (result:bool)"},
+
 	{"EqString", "op", "(x:string y:string) -> (result:bool)"},
 	{"NotEqString", "op", "(x:string y:string) -> (result:bool)"},
 
 	{"Concat", "op", "(x:string y:string) -> (result:string)"},
 	{"Add", "op", "(x:int y:int) -> (result:int)"},
 	{"Sub", "op", "(x:int y:int) -> (result:int)"},
+	{"Mul", "op", "(x:int y:int) -> (result:int)"},
+	{"Div", "op", "(x:int y:int) -> (result:int)"},
+	{"AddFloat", "op", "(x:float64 y:float64) -> (result:float64)"},
+	{"SubFloat", "op", "(x:float64 y:float64) -> (result:float64)"},
+	{"MulFloat", "op", "(x:float64 y:float64) -> (result:float64)"},
+	{"DivFloat", "op", "(x:float64 y:float64) -> (result:float64)"},
 
 	{"StringSlice", "op", "(s:string from:int to:int) -> (result:string)"},
 	{"StringSliceFrom", "op", "(s:string from:int) -> (result:string)"},
 	{"StringSliceTo", "op", "(s:string to:int) -> (result:string)"},
 	{"StringLen", "op", "(s:string) -> (result:int)"},
+	{"StringIndex", "op", "(s:string index:int) -> (result:int)"},
+	{"StringDecodeRune", "op", "(s:string index:int) -> (ch:int size:int)"},
+
+	{"MakeSlice", "op zeroconstid:u8", "(len:int cap:int) -> (slice)"},
+	{"MakeStringSlice", "op", "(len:int cap:int) -> (slice)"},
+	{"MakeIntSlice", "op", "(len:int cap:int) -> (slice)"},
+	{"SliceLen", "op", "(s) -> (result:int)"},
+	{"SliceIndex", "op", "(s index:int) -> (value)"},
+	{"StringSliceIndex", "op", "(s index:int) -> (value:string)"},
+	{"IntSliceIndex", "op", "(s index:int) -> (value:int)"},
+	{"Append", "op", "(s value) -> (result)"},
+	{"StringAppend", "op", "(s value:string) -> (result)"},
+	{"IntAppend", "op", "(s value:int) -> (result)"},
+
+	{"MakeMap", "op", "() -> (m)"},
+	{"MakeIntMap", "op", "() -> (m)"},
+	{"MapLen", "op", "(m) -> (result:int)"},
+	{"MapIndex", "op zeroconstid:u8", "(m key:string) -> (value)"},
+	{"IntMapIndex", "op", "(m key:string) -> (value:int)"},
+	{"SetMapIndex", "op", "(m key:string value) -> ()"},
+	{"SetIntMapIndex", "op", "(m key:string value:int) -> ()"},
+	{"MapKeys", "op", "(m) -> (keys)"},
 }
 
 type opcodeProto struct {
@@ -10,56 +10,105 @@ func _() {
 	var x [1]struct{}
 	_ = x[opInvalid-0]
 	_ = x[opPop-1]
-	_ = x[opDup-2]
-	_ = x[opPushParam-3]
-	_ = x[opPushIntParam-4]
-	_ = x[opPushLocal-5]
-	_ = x[opPushIntLocal-6]
-	_ = x[opPushFalse-7]
-	_ = x[opPushTrue-8]
-	_ = x[opPushConst-9]
-	_ = x[opPushIntConst-10]
-	_ = x[opConvIntToIface-11]
-	_ = x[opSetLocal-12]
-	_ = x[opSetIntLocal-13]
-	_ = x[opIncLocal-14]
-	_ = x[opDecLocal-15]
-	_ = x[opReturnTop-16]
-	_ = x[opReturnIntTop-17]
-	_ = x[opReturnFalse-18]
-	_ = x[opReturnTrue-19]
-	_ = x[opReturn-20]
-	_ = x[opJump-21]
-	_ = x[opJumpFalse-22]
-	_ = x[opJumpTrue-23]
-	_ = x[opSetVariadicLen-24]
-	_ = x[opCallNative-25]
-	_ = x[opCall-26]
-	_ = x[opIntCall-27]
-	_ = x[opVoidCall-28]
-	_ = x[opIsNil-29]
-	_ = x[opIsNotNil-30]
-	_ = x[opNot-31]
-	_ = x[opEqInt-32]
-	_ = x[opNotEqInt-33]
-	_ = x[opGtInt-34]
-	_ = x[opGtEqInt-35]
-	_ = x[opLtInt-36]
-	_ = x[opLtEqInt-37]
-	_ = x[opEqString-38]
-	_ = x[opNotEqString-39]
-	_ = x[opConcat-40]
-	_ = x[opAdd-41]
-	_ = x[opSub-42]
-	_ = x[opStringSlice-43]
-	_ = x[opStringSliceFrom-44]
-	_ = x[opStringSliceTo-45]
-	_ = x[opStringLen-46]
+	_ = x[opPopInt-2]
+	_ = x[opDup-3]
+	_ = x[opPushParam-4]
+	_ = x[opPushIntParam-5]
+	_ = x[opPushLocal-6]
+	_ = x[opPushIntLocal-7]
+	_ = x[opPushFalse-8]
+	_ = x[opPushTrue-9]
+	_ = x[opPushConst-10]
+	_ = x[opPushIntConst-11]
+	_ = x[opConvIntToIface-12]
+	_ = x[opConvIntToByte-13]
+	_ = x[opConvIntToRune-14]
+	_ = x[opConvIntToFloat-15]
+	_ = x[opConvFloatToInt-16]
+	_ = x[opConvRuneToString-17]
+	_ = x[opSetLocal-18]
+	_ = x[opSetIntLocal-19]
+	_ = x[opIncLocal-20]
+	_ = x[opDecLocal-21]
+	_ = x[opReturnTop-22]
+	_ = x[opReturnIntTop-23]
+	_ = x[opReturnFalse-24]
+	_ = x[opReturnTrue-25]
+	_ = x[opReturn-26]
+	_ = x[opReturnMulti-27]
+	_ = x[opJump-28]
+	_ = x[opJumpFalse-29]
+	_ = x[opJumpTrue-30]
+	_ = x[opSetVariadicLen-31]
+	_ = x[opCallNative-32]
+	_ = x[opCall-33]
+	_ = x[opIntCall-34]
+	_ = x[opVoidCall-35]
+	_ = x[opMultiCall-36]
+	_ = x[opCallRecur-37]
+	_ = x[opIntCallRecur-38]
+	_ = x[opVoidCallRecur-39]
+	_ = x[opMultiCallRecur-40]
+	_ = x[opMakeClosure-41]
+	_ = x[opCallClosure-42]
+	_ = x[opIntCallClosure-43]
+	_ = x[opVoidCallClosure-44]
+	_ = x[opMultiCallClosure-45]
+	_ = x[opIsNil-46]
+	_ = x[opIsNotNil-47]
+	_ = x[opNot-48]
+	_ = x[opEqInt-49]
+	_ = x[opNotEqInt-50]
+	_ = x[opGtInt-51]
+	_ = x[opGtEqInt-52]
+	_ = x[opLtInt-53]
+	_ = x[opLtEqInt-54]
+	_ = x[opEqFloat-55]
+	_ = x[opNotEqFloat-56]
+	_ = x[opGtFloat-57]
+	_ = x[opGtEqFloat-58]
+	_ = x[opLtFloat-59]
+	_ = x[opLtEqFloat-60]
+	_ = x[opEqString-61]
+	_ = x[opNotEqString-62]
+	_ = x[opConcat-63]
+	_ = x[opAdd-64]
+	_ = x[opSub-65]
+	_ = x[opMul-66]
+	_ = x[opDiv-67]
+	_ = x[opAddFloat-68]
+	_ = x[opSubFloat-69]
+	_ = x[opMulFloat-70]
+	_ = x[opDivFloat-71]
+	_ = x[opStringSlice-72]
+	_ = x[opStringSliceFrom-73]
+	_ = x[opStringSliceTo-74]
+	_ = x[opStringLen-75]
+	_ = x[opStringIndex-76]
+	_ = x[opStringDecodeRune-77]
+	_ = x[opMakeSlice-78]
+	_ = x[opMakeStringSlice-79]
+	_ = x[opMakeIntSlice-80]
+	_ = x[opSliceLen-81]
+	_ = x[opSliceIndex-82]
+	_ = x[opStringSliceIndex-83]
+	_ = x[opIntSliceIndex-84]
+	_ = x[opAppend-85]
+	_ = x[opStringAppend-86]
+	_ = x[opIntAppend-87]
+	_ = x[opMakeMap-88]
+	_ = x[opMakeIntMap-89]
+	_ = x[opMapLen-90]
+	_ = x[opMapIndex-91]
+	_ = x[opIntMapIndex-92]
+	_ = x[opSetMapIndex-93]
+	_ = x[opSetIntMapIndex-94]
+	_ = x[opMapKeys-95]
 }
 
-const _opcode_name = "InvalidPopDupPushParamPushIntParamPushLocalPushIntLocalPushFalsePushTruePushConstPushIntConstConvIntToIfaceSetLocalSetIntLocalIncLocalDecLocalReturnTopReturnIntTopReturnFalseReturnTrueReturnJumpJumpFalseJumpTrueSetVariadicLenCallNativeCallIntCallVoidCallIsNilIsNotNilNotEqIntNotEqIntGtIntGtEqIntLtIntLtEqIntEqStringNotEqStringConcatAddSubStringSliceStringSliceFromStringSliceToStringLen"
+const _opcode_name = "InvalidPopPopIntDupPushParamPushIntParamPushLocalPushIntLocalPushFalsePushTruePushConstPushIntConstConvIntToIfaceConvIntToByteConvIntToRuneConvIntToFloatConvFloatToIntConvRuneToStringSetLocalSetIntLocalIncLocalDecLocalReturnTopReturnIntTopReturnFalseReturnTrueReturnReturnMultiJumpJumpFalseJumpTrueSetVariadicLenCallNativeCallIntCallVoidCallMultiCallCallRecurIntCallRecurVoidCallRecurMultiCallRecurMakeClosureCallClosureIntCallClosureVoidCallClosureMultiCallClosureIsNilIsNotNilNotEqIntNotEqIntGtIntGtEqIntLtIntLtEqIntEqFloatNotEqFloatGtFloatGtEqFloatLtFloatLtEqFloatEqStringNotEqStringConcatAddSubMulDivAddFloatSubFloatMulFloatDivFloatStringSliceStringSliceFromStringSliceToStringLenStringIndexStringDecodeRuneMakeSliceMakeStringSliceMakeIntSliceSliceLenSliceIndexStringSliceIndexIntSliceIndexAppendStringAppendIntAppendMakeMapMakeIntMapMapLenMapIndexIntMapIndexSetMapIndexSetIntMapIndexMapKeys"
 
-var _opcode_index = [...]uint16{0, 7, 10, 13, 22, 34, 43, 55, 64, 72, 81, 93, 107, 115, 126, 134, 142, 151, 163, 174, 184, 190, 194, 203, 211, 225, 235, 239, 246, 254, 259, 267, 270, 275, 283, 288, 295, 300, 307, 315, 326, 332, 335, 338, 349, 364, 377, 386}
+var _opcode_index = [...]uint16{0, 7, 10, 16, 19, 28, 40, 49, 61, 70, 78, 87, 99, 113, 126, 139, 153, 167, 183, 191, 202, 210, 218, 227, 239, 250, 260, 266, 277, 281, 290, 298, 312, 322, 326, 333, 341, 350, 359, 371, 384, 398, 409, 420, 434, 449, 465, 470, 478, 481, 486, 494, 499, 506, 511, 518, 525, 535, 542, 551, 558, 567, 575, 586, 592, 595, 598, 601, 604, 612, 620, 628, 636, 647, 662, 675, 684, 695, 711, 720, 735, 747, 755, 765, 781, 794, 800, 812, 821, 828, 838, 844, 852, 863, 874, 888, 895}
 
 func (i opcode) String() string {
 	if i >= opcode(len(_opcode_index)-1) {
@@ -13,184 +13,380 @@ const (
 	opPop opcode = 1
 
 	// Encoding: 0x02 (width=1)
+	// Stack effect: (value:int) -> ()
+	opPopInt opcode = 2
+
+	// Encoding: 0x03 (width=1)
 	// Stack effect: (x) -> (x x)
-	opDup opcode = 2
+	opDup opcode = 3
 
-	// Encoding: 0x03 index:u8 (width=2)
+	// Encoding: 0x04 index:u8 (width=2)
 	// Stack effect: () -> (value)
-	opPushParam opcode = 3
+	opPushParam opcode = 4
 
-	// Encoding: 0x04 index:u8 (width=2)
+	// Encoding: 0x05 index:u8 (width=2)
 	// Stack effect: () -> (value:int)
-	opPushIntParam opcode = 4
+	opPushIntParam opcode = 5
 
-	// Encoding: 0x05 index:u8 (width=2)
+	// Encoding: 0x06 index:u8 (width=2)
 	// Stack effect: () -> (value)
-	opPushLocal opcode = 5
+	opPushLocal opcode = 6
 
-	// Encoding: 0x06 index:u8 (width=2)
+	// Encoding: 0x07 index:u8 (width=2)
 	// Stack effect: () -> (value:int)
-	opPushIntLocal opcode = 6
+	opPushIntLocal opcode = 7
 
-	// Encoding: 0x07 (width=1)
+	// Encoding: 0x08 (width=1)
 	// Stack effect: () -> (false)
-	opPushFalse opcode = 7
+	opPushFalse opcode = 8
 
-	// Encoding: 0x08 (width=1)
+	// Encoding: 0x09 (width=1)
 	// Stack effect: () -> (true)
-	opPushTrue opcode = 8
+	opPushTrue opcode = 9
 
-	// Encoding: 0x09 constid:u8 (width=2)
+	// Encoding: 0x0a constid:u8 (width=2)
 	// Stack effect: () -> (const)
-	opPushConst opcode = 9
+	opPushConst opcode = 10
 
-	// Encoding: 0x0a constid:u8 (width=2)
+	// Encoding: 0x0b constid:u8 (width=2)
 	// Stack effect: () -> (const:int)
-	opPushIntConst opcode = 10
+	opPushIntConst opcode = 11
 
-	// Encoding: 0x0b (width=1)
+	// Encoding: 0x0c (width=1)
 	// Stack effect: (value:int) -> (value)
-	opConvIntToIface opcode = 11
+	opConvIntToIface opcode = 12
 
-	// Encoding: 0x0c index:u8 (width=2)
+	// Encoding: 0x0d (width=1)
+	// Stack effect: (value:int) -> (result:int)
+	opConvIntToByte opcode = 13
+
+	// Encoding: 0x0e (width=1)
+	// Stack effect: (value:int) -> (result:int)
+	opConvIntToRune opcode = 14
+
+	// Encoding: 0x0f (width=1)
+	// Stack effect: (value:int) -> (result:float64)
+	opConvIntToFloat opcode = 15
+
+	// Encoding: 0x10 (width=1)
+	// Stack effect: (value:float64) -> (result:int)
+	opConvFloatToInt opcode = 16
+
+	// Encoding: 0x11 (width=1)
+	// Stack effect: (value:int) -> (result:string)
+	opConvRuneToString opcode = 17
+
+	// Encoding: 0x12 index:u8 (width=2)
 	// Stack effect: (value) -> ()
-	opSetLocal opcode = 12
+	opSetLocal opcode = 18
 
-	// Encoding: 0x0d index:u8 (width=2)
+	// Encoding: 0x13 index:u8 (width=2)
 	// Stack effect: (value:int) -> ()
-	opSetIntLocal opcode = 13
+	opSetIntLocal opcode = 19
 
-	// Encoding: 0x0e index:u8 (width=2)
+	// Encoding: 0x14 index:u8 (width=2)
 	// Stack effect: unchanged
-	opIncLocal opcode = 14
+	opIncLocal opcode = 20
 
-	// Encoding: 0x0f index:u8 (width=2)
+	// Encoding: 0x15 index:u8 (width=2)
 	// Stack effect: unchanged
-	opDecLocal opcode = 15
+	opDecLocal opcode = 21
 
-	// Encoding: 0x10 (width=1)
+	// Encoding: 0x16 (width=1)
 	// Stack effect: (value) -> (value)
-	opReturnTop opcode = 16
+	opReturnTop opcode = 22
 
-	// Encoding: 0x11 (width=1)
+	// Encoding: 0x17 (width=1)
 	// Stack effect: (value) -> (value)
-	opReturnIntTop opcode = 17
+	opReturnIntTop opcode = 23
 
-	// Encoding: 0x12 (width=1)
+	// Encoding: 0x18 (width=1)
 	// Stack effect: unchanged
-	opReturnFalse opcode = 18
+	opReturnFalse opcode = 24
 
-	// Encoding: 0x13 (width=1)
+	// Encoding: 0x19 (width=1)
 	// Stack effect: unchanged
-	opReturnTrue opcode = 19
+	opReturnTrue opcode = 25
 
-	// Encoding: 0x14 (width=1)
+	// Encoding: 0x1a (width=1)
 	// Stack effect: unchanged
-	opReturn opcode = 20
+	opReturn opcode = 26
 
-	// Encoding: 0x15 offset:i16 (width=3)
+	// Encoding: 0x1b (width=1)
+	// Stack effect: (results...) -> (results...)
+	opReturnMulti opcode = 27
+
+	// Encoding: 0x1c offset:i16 (width=3)
 	// Stack effect: unchanged
-	opJump opcode = 21
+	opJump opcode = 28
 
-	// Encoding: 0x16 offset:i16 (width=3)
+	// Encoding: 0x1d offset:i16 (width=3)
 	// Stack effect: (cond:bool) -> ()
-	opJumpFalse opcode = 22
+	opJumpFalse opcode = 29
 
-	// Encoding: 0x17 offset:i16 (width=3)
+	// Encoding: 0x1e offset:i16 (width=3)
 	// Stack effect: (cond:bool) -> ()
-	opJumpTrue opcode = 23
+	opJumpTrue opcode = 30
 
-	// Encoding: 0x18 len:u8 (width=2)
+	// Encoding: 0x1f len:u8 (width=2)
 	// Stack effect: unchanged
-	opSetVariadicLen opcode = 24
+	opSetVariadicLen opcode = 31
 
-	// Encoding: 0x19 funcid:u16 (width=3)
+	// Encoding: 0x20 funcid:u16 (width=3)
 	// Stack effect: (args...) -> (results...)
-	opCallNative opcode = 25
+	opCallNative opcode = 32
 
-	// Encoding: 0x1a funcid:u16 (width=3)
+	// Encoding: 0x21 funcid:u16 (width=3)
 	// Stack effect: (args...) -> (result)
-	opCall opcode = 26
+	opCall opcode = 33
 
-	// Encoding: 0x1b funcid:u16 (width=3)
+	// Encoding: 0x22 funcid:u16 (width=3)
 	// Stack effect: (args...) -> (result:int)
-	opIntCall opcode = 27
+	opIntCall opcode = 34
 
-	// Encoding: 0x1c funcid:u16 (width=3)
+	// Encoding: 0x23 funcid:u16 (width=3)
 	// Stack effect: (args...) -> ()
-	opVoidCall opcode = 28
+	opVoidCall opcode = 35
+
+	// Encoding: 0x24 funcid:u16 (width=3)
+	// Stack effect: (args...) -> (results...)
+	opMultiCall opcode = 36
+
+	// Encoding: 0x25 (width=1)
+	// Stack effect: (args...) -> (result)
+	opCallRecur opcode = 37
+
+	// Encoding: 0x26 (width=1)
+	// Stack effect: (args...) -> (result:int)
+	opIntCallRecur opcode = 38
+
+	// Encoding: 0x27 (width=1)
+	// Stack effect: (args...) -> ()
+	opVoidCallRecur opcode = 39
+
+	// Encoding: 0x28 (width=1)
+	// Stack effect: (args...) -> (results...)
+	opMultiCallRecur opcode = 40
+
+	// Encoding: 0x29 constid:u8 (width=2)
+	// Stack effect: (captured...) -> (closure)
+	opMakeClosure opcode = 41
+
+	// Encoding: 0x2a (width=1)
+	// Stack effect: (args... closure) -> (result)
+	opCallClosure opcode = 42
+
+	// Encoding: 0x2b (width=1)
+	// Stack effect: (args... closure) -> (result:int)
+	opIntCallClosure opcode = 43
+
+	// Encoding: 0x2c (width=1)
+	// Stack effect: (args... closure) -> ()
+	opVoidCallClosure opcode = 44
+
+	// Encoding: 0x2d (width=1)
+	// Stack effect: (args... closure) -> (results...)
+	opMultiCallClosure opcode = 45
 
-	// Encoding: 0x1d (width=1)
+	// Encoding: 0x2e (width=1)
 	// Stack effect: (value) -> (result:bool)
-	opIsNil opcode = 29
+	opIsNil opcode = 46
 
-	// Encoding: 0x1e (width=1)
+	// Encoding: 0x2f (width=1)
 	// Stack effect: (value) -> (result:bool)
-	opIsNotNil opcode = 30
+	opIsNotNil opcode = 47
 
-	// Encoding: 0x1f (width=1)
+	// Encoding: 0x30 (width=1)
 	// Stack effect: (value:bool) -> (result:bool)
-	opNot opcode = 31
+	opNot opcode = 48
 
-	// Encoding: 0x20 (width=1)
+	// Encoding: 0x31 (width=1)
 	// Stack effect: (x:int y:int) -> (result:bool)
-	opEqInt opcode = 32
+	opEqInt opcode = 49
 
-	// Encoding: 0x21 (width=1)
+	// Encoding: 0x32 (width=1)
 	// Stack effect: (x:int y:int) -> (result:bool)
-	opNotEqInt opcode = 33
+	opNotEqInt opcode = 50
 
-	// Encoding: 0x22 (width=1)
+	// Encoding: 0x33 (width=1)
 	// Stack effect: (x:int y:int) -> (result:bool)
-	opGtInt opcode = 34
+	opGtInt opcode = 51
 
-	// Encoding: 0x23 (width=1)
+	// Encoding: 0x34 (width=1)
 	// Stack effect: (x:int y:int) -> (result:bool)
-	opGtEqInt opcode = 35
+	opGtEqInt opcode = 52
 
-	// Encoding: 0x24 (width=1)
+	// Encoding: 0x35 (width=1)
 	// Stack effect: (x:int y:int) -> (result:bool)
-	opLtInt opcode = 36
+	opLtInt opcode = 53
 
-	// Encoding: 0x25 (width=1)
+	// Encoding: 0x36 (width=1)
 	// Stack effect: (x:int y:int) -> (result:bool)
-	opLtEqInt opcode = 37
+	opLtEqInt opcode = 54
 
-	// Encoding: 0x26 (width=1)
+	// Encoding: 0x37 (width=1)
+	// Stack effect: (x:float64 y:float64) -> (result:bool)
+	opEqFloat opcode = 55
+
+	// Encoding: 0x38 (width=1)
+	// Stack effect: (x:float64 y:float64) -> (result:bool)
+	opNotEqFloat opcode = 56
+
+	// Encoding: 0x39 (width=1)
+	// Stack effect: (x:float64 y:float64) -> (result:bool)
+	opGtFloat opcode = 57
+
+	// Encoding: 0x3a (width=1)
+	// Stack effect: (x:float64 y:float64) -> (result:bool)
+	opGtEqFloat opcode = 58
+
+	// Encoding: 0x3b (width=1)
+	// Stack effect: (x:float64 y:float64) -> (result:bool)
+	opLtFloat opcode = 59
+
+	// Encoding: 0x3c (width=1)
+	// Stack effect: (x:float64 y:float64) -> (result:bool)
+	opLtEqFloat opcode = 60
+
+	// Encoding: 0x3d (width=1)
 	// Stack effect: (x:string y:string) -> (result:bool)
-	opEqString opcode = 38
+	opEqString opcode = 61
 
-	// Encoding: 0x27 (width=1)
+	// Encoding: 0x3e (width=1)
 	// Stack effect: (x:string y:string) -> (result:bool)
-	opNotEqString opcode = 39
+	opNotEqString opcode = 62
 
-	// Encoding: 0x28 (width=1)
+	// Encoding: 0x3f (width=1)
 	// Stack effect: (x:string y:string) -> (result:string)
-	opConcat opcode = 40
+	opConcat opcode = 63
 
-	// Encoding: 0x29 (width=1)
+	// Encoding: 0x40 (width=1)
 	// Stack effect: (x:int y:int) -> (result:int)
-	opAdd opcode = 41
+	opAdd opcode = 64
 
-	// Encoding: 0x2a (width=1)
+	// Encoding: 0x41 (width=1)
 	// Stack effect: (x:int y:int) -> (result:int)
-	opSub opcode = 42
+	opSub opcode = 65
 
-	// Encoding: 0x2b (width=1)
+	// Encoding: 0x42 (width=1)
+	// Stack effect: (x:int y:int) -> (result:int)
+	opMul opcode = 66
+
+	// Encoding: 0x43 (width=1)
+	// Stack effect: (x:int y:int) -> (result:int)
+	opDiv opcode = 67
+
+	// Encoding: 0x44 (width=1)
+	// Stack effect: (x:float64 y:float64) -> (result:float64)
+	opAddFloat opcode = 68
+
+	// Encoding: 0x45 (width=1)
+	// Stack effect: (x:float64 y:float64) -> (result:float64)
+	opSubFloat opcode = 69
+
+	// Encoding: 0x46 (width=1)
+	// Stack effect: (x:float64 y:float64) -> (result:float64)
+	opMulFloat opcode = 70
+
+	// Encoding: 0x47 (width=1)
+	// Stack effect: (x:float64 y:float64) -> (result:float64)
+	opDivFloat opcode = 71
+
+	// Encoding: 0x48 (width=1)
 	// Stack effect: (s:string from:int to:int) -> (result:string)
-	opStringSlice opcode = 43
+	opStringSlice opcode = 72
 
-	// Encoding: 0x2c (width=1)
+	// Encoding: 0x49 (width=1)
 	// Stack effect: (s:string from:int) -> (result:string)
-	opStringSliceFrom opcode = 44
+	opStringSliceFrom opcode = 73
 
-	// Encoding: 0x2d (width=1)
+	// Encoding: 0x4a (width=1)
 	// Stack effect: (s:string to:int) -> (result:string)
-	opStringSliceTo opcode = 45
+	opStringSliceTo opcode = 74
 
-	// Encoding: 0x2e (width=1)
+	// Encoding: 0x4b (width=1)
 	// Stack effect: (s:string) -> (result:int)
-	opStringLen opcode = 46
+	opStringLen opcode = 75
+
+	// Encoding: 0x4c (width=1)
+	// Stack effect: (s:string index:int) -> (result:int)
+	opStringIndex opcode = 76
+
+	// Encoding: 0x4d (width=1)
+	// Stack effect: (s:string index:int) -> (ch:int size:int)
+	opStringDecodeRune opcode = 77
+
+	// Encoding: 0x4e zeroconstid:u8 (width=2)
+	// Stack effect: (len:int cap:int) -> (slice)
+	opMakeSlice opcode = 78
+
+	// Encoding: 0x4f (width=1)
+	// Stack effect: (len:int cap:int) -> (slice)
+	opMakeStringSlice opcode = 79
+
+	// Encoding: 0x50 (width=1)
+	// Stack effect: (len:int cap:int) -> (slice)
+	opMakeIntSlice opcode = 80
+
+	// Encoding: 0x51 (width=1)
+	// Stack effect: (s) -> (result:int)
+	opSliceLen opcode = 81
+
+	// Encoding: 0x52 (width=1)
+	// Stack effect: (s index:int) -> (value)
+	opSliceIndex opcode = 82
+
+	// Encoding: 0x53 (width=1)
+	// Stack effect: (s index:int) -> (value:string)
+	opStringSliceIndex opcode = 83
+
+	// Encoding: 0x54 (width=1)
+	// Stack effect: (s index:int) -> (value:int)
+	opIntSliceIndex opcode = 84
+
+	// Encoding: 0x55 (width=1)
+	// Stack effect: (s value) -> (result)
+	opAppend opcode = 85
+
+	// Encoding: 0x56 (width=1)
+	// Stack effect: (s value:string) -> (result)
+	opStringAppend opcode = 86
+
+	// Encoding: 0x57 (width=1)
+	// Stack effect: (s value:int) -> (result)
+	opIntAppend opcode = 87
+
+	// Encoding: 0x58 (width=1)
+	// Stack effect: () -> (m)
+	opMakeMap opcode = 88
+
+	// Encoding: 0x59 (width=1)
+	// Stack effect: () -> (m)
+	opMakeIntMap opcode = 89
+
+	// Encoding: 0x5a (width=1)
+	// Stack effect: (m) -> (result:int)
+	opMapLen opcode = 90
+
+	// Encoding: 0x5b zeroconstid:u8 (width=2)
+	// Stack effect: (m key:string) -> (value)
+	opMapIndex opcode = 91
+
+	// Encoding: 0x5c (width=1)
+	// Stack effect: (m key:string) -> (value:int)
+	opIntMapIndex opcode = 92
+
+	// Encoding: 0x5d (width=1)
+	// Stack effect: (m key:string value) -> ()
+	opSetMapIndex opcode = 93
+
+	// Encoding: 0x5e (width=1)
+	// Stack effect: (m key:string value:int) -> ()
+	opSetIntMapIndex opcode = 94
+
+	// Encoding: 0x5f (width=1)
+	// Stack effect: (m) -> (keys)
+	opMapKeys opcode = 95
 )
 
 type opcodeInfo struct {
@@ -200,50 +396,99 @@ type opcodeInfo struct {
 var opcodeInfoTable = [256]opcodeInfo{
 	opInvalid: {width: 1},
 
-	opPop:             {width: 1},
-	opDup:             {width: 1},
-	opPushParam:       {width: 2},
-	opPushIntParam:    {width: 2},
-	opPushLocal:       {width: 2},
-	opPushIntLocal:    {width: 2},
-	opPushFalse:       {width: 1},
-	opPushTrue:        {width: 1},
-	opPushConst:       {width: 2},
-	opPushIntConst:    {width: 2},
-	opConvIntToIface:  {width: 1},
-	opSetLocal:        {width: 2},
-	opSetIntLocal:     {width: 2},
-	opIncLocal:        {width: 2},
-	opDecLocal:        {width: 2},
-	opReturnTop:       {width: 1},
-	opReturnIntTop:    {width: 1},
-	opReturnFalse:     {width: 1},
-	opReturnTrue:      {width: 1},
-	opReturn:          {width: 1},
-	opJump:            {width: 3},
-	opJumpFalse:       {width: 3},
-	opJumpTrue:        {width: 3},
-	opSetVariadicLen:  {width: 2},
-	opCallNative:      {width: 3},
-	opCall:            {width: 3},
-	opIntCall:         {width: 3},
-	opVoidCall:        {width: 3},
-	opIsNil:           {width: 1},
-	opIsNotNil:        {width: 1},
-	opNot:             {width: 1},
-	opEqInt:           {width: 1},
-	opNotEqInt:        {width: 1},
-	opGtInt:           {width: 1},
-	opGtEqInt:         {width: 1},
-	opLtInt:           {width: 1},
-	opLtEqInt:         {width: 1},
-	opEqString:        {width: 1},
-	opNotEqString:     {width: 1},
-	opConcat:          {width: 1},
-	opAdd:             {width: 1},
-	opSub:             {width: 1},
-	opStringSlice:     {width: 1},
-	opStringSliceFrom: {width: 1},
-	opStringSliceTo:   {width: 1},
-	opStringLen:       {width: 1},
+	opPop:              {width: 1},
+	opPopInt:           {width: 1},
+	opDup:              {width: 1},
+	opPushParam:        {width: 2},
+	opPushIntParam:     {width: 2},
+	opPushLocal:        {width: 2},
+	opPushIntLocal:     {width: 2},
+	opPushFalse:        {width: 1},
+	opPushTrue:         {width: 1},
+	opPushConst:        {width: 2},
+	opPushIntConst:     {width: 2},
+	opConvIntToIface:   {width: 1},
+	opConvIntToByte:    {width: 1},
+	opConvIntToRune:    {width: 1},
+	opConvIntToFloat:   {width: 1},
+	opConvFloatToInt:   {width: 1},
+	opConvRuneToString: {width: 1},
+	opSetLocal:         {width: 2},
+	opSetIntLocal:      {width: 2},
+	opIncLocal:         {width: 2},
+	opDecLocal:         {width: 2},
+	opReturnTop:        {width: 1},
+	opReturnIntTop:     {width: 1},
+	opReturnFalse:      {width: 1},
+	opReturnTrue:       {width: 1},
+	opReturn:           {width: 1},
+	opReturnMulti:      {width: 1},
+	opJump:             {width: 3},
+	opJumpFalse:        {width: 3},
+	opJumpTrue:         {width: 3},
+	opSetVariadicLen:   {width: 2},
+	opCallNative:       {width: 3},
+	opCall:             {width: 3},
+	opIntCall:          {width: 3},
+	opVoidCall:         {width: 3},
+	opMultiCall:        {width: 3},
+	opCallRecur:        {width: 1},
+	opIntCallRecur:     {width: 1},
+	opVoidCallRecur:    {width: 1},
+	opMultiCallRecur:   {width: 1},
+	opMakeClosure:      {width: 2},
+	opCallClosure:      {width: 1},
+	opIntCallClosure:   {width: 1},
+	opVoidCallClosure:  {width: 1},
+	opMultiCallClosure: {width: 1},
+	opIsNil:            {width: 1},
+	opIsNotNil:         {width: 1},
+	opNot:              {width: 1},
+	opEqInt:            {width: 1},
+	opNotEqInt:         {width: 1},
+	opGtInt:            {width: 1},
+	opGtEqInt:          {width: 1},
+	opLtInt:            {width: 1},
+	opLtEqInt:          {width: 1},
+	opEqFloat:          {width: 1},
+	opNotEqFloat:       {width: 1},
+	opGtFloat:          {width: 1},
+	opGtEqFloat:        {width: 1},
+	opLtFloat:          {width: 1},
+	opLtEqFloat:        {width: 1},
+	opEqString:         {width: 1},
+	opNotEqString:      {width: 1},
+	opConcat:           {width: 1},
+	opAdd:              {width: 1},
+	opSub:              {width: 1},
+	opMul:              {width: 1},
+	opDiv:              {width: 1},
+	opAddFloat:         {width: 1},
+	opSubFloat:         {width: 1},
+	opMulFloat:         {width: 1},
+	opDivFloat:         {width: 1},
+	opStringSlice:      {width: 1},
+	opStringSliceFrom:  {width: 1},
+	opStringSliceTo:    {width: 1},
+	opStringLen:        {width: 1},
+	opStringIndex:      {width: 1},
+	opStringDecodeRune: {width: 1},
+	opMakeSlice:        {width: 2},
+	opMakeStringSlice:  {width: 1},
+	opMakeIntSlice:     {width: 1},
+	opSliceLen:         {width: 1},
+	opSliceIndex:       {width: 1},
+	opStringSliceIndex: {width: 1},
+	opIntSliceIndex:    {width: 1},
+	opAppend:           {width: 1},
+	opStringAppend:     {width: 1},
+	opIntAppend:        {width: 1},
+	opMakeMap:          {width: 1},
+	opMakeIntMap:       {width: 1},
+	opMapLen:           {width: 1},
+	opMapIndex:         {width: 2},
+	opIntMapIndex:      {width: 1},
+	opSetMapIndex:      {width: 1},
+	opSetIntMapIndex:   {width: 1},
+	opMapKeys:          {width: 1},
 }
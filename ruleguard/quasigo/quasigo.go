@@ -4,6 +4,7 @@
 package quasigo
 
 import (
+	"fmt"
 	"go/ast"
 	"go/token"
 	"go/types"
@@ -34,6 +35,9 @@ type EvalEnv struct {
 	nativeFuncs []nativeFunc
 	userFuncs   []*Func
 
+	// callDepth is a number of the active user func calls.
+	callDepth int
+
 	Stack ValueStack
 }
 
@@ -100,13 +104,31 @@ func Compile(ctx *CompileContext, fn *ast.FuncDecl) (compiled *Func, err error)
 // Note that arguments are not popped off the stack,
 // so you can bind the args once and use Call multiple times.
 // If you want to reset arguments, do env.Stack.Reset().
+//
+// If the user func calls nesting exceeds the limit, Call panics
+// with a *CallDepthError value. The env can be used after
+// such panic is recovered: its state is restored on unwind.
 func Call(env *EvalEnv, fn *Func) CallResult {
 	numObjectArgs := len(env.Stack.objects)
 	numIntArgs := len(env.Stack.ints)
-	result := eval(env, fn, 0, 0)
-	env.Stack.objects = env.Stack.objects[:numObjectArgs]
-	env.Stack.ints = env.Stack.ints[:numIntArgs]
-	return result
+	callDepth := env.callDepth
+	defer func() {
+		env.Stack.objects = env.Stack.objects[:numObjectArgs]
+		env.Stack.ints = env.Stack.ints[:numIntArgs]
+		env.callDepth = callDepth
+	}()
+	return eval(env, fn, 0, 0)
+}
+
+// CallDepthError is a panic value that is used when the
+// user func calls nesting is too deep (usually, it's a runaway recursion).
+type CallDepthError struct {
+	// FuncName is a name of the func that exceeded the limit.
+	FuncName string
+}
+
+func (e *CallDepthError) Error() string {
+	return fmt.Sprintf("quasigo: %s: max call depth (%d) exceeded", e.FuncName, maxCallDepth)
 }
 
 // CallResult is a return value of Call function.
@@ -141,6 +163,16 @@ type Func struct {
 	numObjectParams int
 	numIntParams    int
 
+	// Only multi-value funcs have these set,
+	// a single result is returned via CallResult.
+	numObjectResults int
+	numIntResults    int
+
+	// Func literals receive the captured values as extra params.
+	// The numCaptured and numIntCaptured are included into the params count.
+	numCaptured    int
+	numIntCaptured int
+
 	name string
 }
 
@@ -151,6 +183,7 @@ type Func struct {
 // For the sake of efficiency, it stores different types separately.
 // If int was pushed with PushInt(), it should be retrieved by PopInt().
 // It's a bad idea to do a Push() and then PopInt() and vice-versa.
+// Bytes and runes are stored as ints too, while float64 values are boxed.
 type ValueStack struct {
 	objects     []interface{}
 	ints        []int
@@ -0,0 +1,19 @@
+package qfilepath
+
+import (
+	"path/filepath"
+
+	"github.com/quasilyte/go-ruleguard/ruleguard/quasigo"
+)
+
+func ImportAll(env *quasigo.Env) {
+	env.AddNativeFunc(`path/filepath`, `Match`, Match)
+}
+
+func Match(stack *quasigo.ValueStack) {
+	name := stack.Pop().(string)
+	pattern := stack.Pop().(string)
+	matched, err := filepath.Match(pattern, name)
+	stack.Push(matched)
+	stack.Push(err)
+}
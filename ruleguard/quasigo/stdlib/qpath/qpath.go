@@ -0,0 +1,22 @@
+package qpath
+
+import (
+	"path"
+
+	"github.com/quasilyte/go-ruleguard/ruleguard/quasigo"
+)
+
+func ImportAll(env *quasigo.Env) {
+	env.AddNativeFunc(`path`, `Base`, Base)
+	env.AddNativeFunc(`path`, `Ext`, Ext)
+}
+
+func Base(stack *quasigo.ValueStack) {
+	s := stack.Pop().(string)
+	stack.Push(path.Base(s))
+}
+
+func Ext(stack *quasigo.ValueStack) {
+	s := stack.Pop().(string)
+	stack.Push(path.Ext(s))
+}
@@ -0,0 +1,60 @@
+package qregexp
+
+import (
+	"regexp"
+	"sync"
+
+	"github.com/quasilyte/go-ruleguard/ruleguard/quasigo"
+)
+
+func ImportAll(env *quasigo.Env) {
+	env.AddNativeFunc(`regexp`, `MatchString`, MatchString)
+}
+
+func MatchString(stack *quasigo.ValueStack) {
+	s := stack.Pop().(string)
+	pattern := stack.Pop().(string)
+	re, err := compile(pattern)
+	if err != nil {
+		stack.Push(false)
+		stack.Push(err)
+		return
+	}
+	stack.Push(re.MatchString(s))
+	stack.Push(nil)
+}
+
+// maxCachedPatterns limits the cache size in case
+// the patterns are constructed dynamically.
+const maxCachedPatterns = 256
+
+// cache maps the patterns to their compiled forms.
+// Filters usually use a few constant patterns, so it's wasteful
+// to compile them on every MatchString call.
+//
+// Functions can be executed concurrently, so the cache is synchronized.
+var cache = struct {
+	sync.Mutex
+	patterns map[string]compiledPattern
+}{
+	patterns: make(map[string]compiledPattern),
+}
+
+type compiledPattern struct {
+	re  *regexp.Regexp
+	err error
+}
+
+func compile(pattern string) (*regexp.Regexp, error) {
+	cache.Lock()
+	defer cache.Unlock()
+	if p, ok := cache.patterns[pattern]; ok {
+		return p.re, p.err
+	}
+	re, err := regexp.Compile(pattern)
+	if len(cache.patterns) == maxCachedPatterns {
+		cache.patterns = make(map[string]compiledPattern)
+	}
+	cache.patterns[pattern] = compiledPattern{re: re, err: err}
+	return re, err
+}
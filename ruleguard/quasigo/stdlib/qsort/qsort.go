@@ -0,0 +1,23 @@
+package qsort
+
+import (
+	"sort"
+
+	"github.com/quasilyte/go-ruleguard/ruleguard/quasigo"
+)
+
+func ImportAll(env *quasigo.Env) {
+	env.AddNativeFunc(`sort`, `Strings`, Strings)
+	env.AddNativeFunc(`sort`, `Ints`, Ints)
+}
+
+func Strings(stack *quasigo.ValueStack) {
+	// A nil slice can be represented as a nil interface.
+	xs, _ := stack.Pop().([]string)
+	sort.Strings(xs)
+}
+
+func Ints(stack *quasigo.ValueStack) {
+	xs, _ := stack.Pop().([]int)
+	sort.Ints(xs)
+}
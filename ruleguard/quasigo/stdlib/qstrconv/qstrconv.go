@@ -9,6 +9,8 @@ import (
 func ImportAll(env *quasigo.Env) {
 	env.AddNativeFunc(`strconv`, `Atoi`, Atoi)
 	env.AddNativeFunc(`strconv`, `Itoa`, Itoa)
+	env.AddNativeFunc(`strconv`, `ParseFloat`, ParseFloat)
+	env.AddNativeFunc(`strconv`, `FormatFloat`, FormatFloat)
 }
 
 func Atoi(stack *quasigo.ValueStack) {
@@ -22,3 +24,19 @@ func Itoa(stack *quasigo.ValueStack) {
 	i := stack.PopInt()
 	stack.Push(strconv.Itoa(i))
 }
+
+func ParseFloat(stack *quasigo.ValueStack) {
+	bitSize := stack.PopInt()
+	s := stack.Pop().(string)
+	v, err := strconv.ParseFloat(s, bitSize)
+	stack.Push(v)
+	stack.Push(err)
+}
+
+func FormatFloat(stack *quasigo.ValueStack) {
+	bitSize := stack.PopInt()
+	prec := stack.PopInt()
+	format := stack.PopInt()
+	f := stack.Pop().(float64)
+	stack.Push(strconv.FormatFloat(f, byte(format), prec, bitSize))
+}
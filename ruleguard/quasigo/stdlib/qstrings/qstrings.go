@@ -14,6 +14,13 @@ func ImportAll(env *quasigo.Env) {
 	env.AddNativeFunc(`strings`, `HasPrefix`, HasPrefix)
 	env.AddNativeFunc(`strings`, `HasSuffix`, HasSuffix)
 	env.AddNativeFunc(`strings`, `Contains`, Contains)
+	env.AddNativeFunc(`strings`, `Split`, Split)
+	env.AddNativeFunc(`strings`, `Fields`, Fields)
+	env.AddNativeFunc(`strings`, `ToLower`, ToLower)
+	env.AddNativeFunc(`strings`, `Index`, Index)
+	env.AddNativeFunc(`strings`, `EqualFold`, EqualFold)
+	env.AddNativeFunc(`strings`, `Count`, Count)
+	env.AddNativeFunc(`strings`, `Repeat`, Repeat)
 }
 
 func Replace(stack *quasigo.ValueStack) {
@@ -60,3 +67,43 @@ func Contains(stack *quasigo.ValueStack) {
 	s := stack.Pop().(string)
 	stack.Push(strings.Contains(s, substr))
 }
+
+func Split(stack *quasigo.ValueStack) {
+	sep := stack.Pop().(string)
+	s := stack.Pop().(string)
+	stack.Push(strings.Split(s, sep))
+}
+
+func Fields(stack *quasigo.ValueStack) {
+	s := stack.Pop().(string)
+	stack.Push(strings.Fields(s))
+}
+
+func ToLower(stack *quasigo.ValueStack) {
+	s := stack.Pop().(string)
+	stack.Push(strings.ToLower(s))
+}
+
+func Index(stack *quasigo.ValueStack) {
+	substr := stack.Pop().(string)
+	s := stack.Pop().(string)
+	stack.PushInt(strings.Index(s, substr))
+}
+
+func EqualFold(stack *quasigo.ValueStack) {
+	t := stack.Pop().(string)
+	s := stack.Pop().(string)
+	stack.Push(strings.EqualFold(s, t))
+}
+
+func Count(stack *quasigo.ValueStack) {
+	substr := stack.Pop().(string)
+	s := stack.Pop().(string)
+	stack.PushInt(strings.Count(s, substr))
+}
+
+func Repeat(stack *quasigo.ValueStack) {
+	count := stack.PopInt()
+	s := stack.Pop().(string)
+	stack.Push(strings.Repeat(s, count))
+}
@@ -0,0 +1,34 @@
+package qunicode
+
+import (
+	"unicode"
+
+	"github.com/quasilyte/go-ruleguard/ruleguard/quasigo"
+)
+
+func ImportAll(env *quasigo.Env) {
+	env.AddNativeFunc(`unicode`, `IsUpper`, IsUpper)
+	env.AddNativeFunc(`unicode`, `IsLower`, IsLower)
+	env.AddNativeFunc(`unicode`, `IsDigit`, IsDigit)
+	env.AddNativeFunc(`unicode`, `IsLetter`, IsLetter)
+}
+
+func IsUpper(stack *quasigo.ValueStack) {
+	r := rune(stack.PopInt())
+	stack.Push(unicode.IsUpper(r))
+}
+
+func IsLower(stack *quasigo.ValueStack) {
+	r := rune(stack.PopInt())
+	stack.Push(unicode.IsLower(r))
+}
+
+func IsDigit(stack *quasigo.ValueStack) {
+	r := rune(stack.PopInt())
+	stack.Push(unicode.IsDigit(r))
+}
+
+func IsLetter(stack *quasigo.ValueStack) {
+	r := rune(stack.PopInt())
+	stack.Push(unicode.IsLetter(r))
+}
@@ -0,0 +1,66 @@
+package main
+
+func any(xs []string, pred func(string) bool) bool {
+	for _, x := range xs {
+		if pred(x) {
+			return true
+		}
+	}
+	return false
+}
+
+func count(xs []int, pred func(int) bool) int {
+	n := 0
+	for _, x := range xs {
+		if pred(x) {
+			n++
+		}
+	}
+	return n
+}
+
+func apply(x int, f func(int) int) int {
+	return f(x)
+}
+
+func each(xs []string, f func(i int, s string)) {
+	for i, x := range xs {
+		f(i, x)
+	}
+}
+
+func main() {
+	words := []string{"foo", "bar", "baz"}
+	prefix := "b"
+	println(any(words, func(s string) bool {
+		return s[:1] == prefix
+	}))
+	println(any(words, func(s string) bool {
+		return s == "qux"
+	}))
+
+	limit := 10
+	println(count([]int{1, 20, 5, 30}, func(x int) bool { return x < limit }))
+
+	add := func(x int) int { return x + limit }
+	println(apply(5, add))
+	println(add(1))
+
+	each(words, func(i int, s string) {
+		println(i)
+		println(s)
+	})
+
+	// Nested func literals.
+	suffix := "!"
+	println(apply(2, func(x int) int {
+		return apply(x, func(y int) int { return y + limit })
+	}))
+	each(words, func(i int, s string) {
+		each([]string{s}, func(j int, s2 string) {
+			println(s2 + suffix)
+		})
+	})
+
+	println(func() string { return prefix + suffix }())
+}
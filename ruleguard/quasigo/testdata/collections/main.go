@@ -0,0 +1,83 @@
+package main
+
+func sum(xs []int) int {
+	total := 0
+	for _, x := range xs {
+		total = total + x
+	}
+	return total
+}
+
+func filterEmpty(list []string) []string {
+	result := []string{}
+	for _, s := range list {
+		if s == "" {
+			continue
+		}
+		result = append(result, s)
+	}
+	return result
+}
+
+func countWords(words []string) map[string]int {
+	counts := make(map[string]int)
+	for _, w := range words {
+		counts[w] = counts[w] + 1
+	}
+	return counts
+}
+
+func totalCount(counts map[string]int) int {
+	total := 0
+	for _, n := range counts {
+		total = total + n
+	}
+	return total
+}
+
+func groupValues() {
+	groups := map[string][]string{}
+	groups["a"] = append(groups["a"], "1")
+	groups["a"] = append(groups["a"], "2")
+	groups["b"] = []string{"3"}
+	// Map iteration order is random in Go, so only
+	// the order-independent results are printed.
+	numValues := 0
+	for k, vs := range groups {
+		if k == "a" {
+			println(vs[len(vs)-1])
+		}
+		numValues = numValues + len(vs)
+	}
+	println(numValues)
+}
+
+func countRunes() {
+	runes := 0
+	for range "привет" {
+		runes++
+	}
+	println(runes)
+	println(len("привет"))
+}
+
+func main() {
+	println(sum([]int{}))
+	println(sum([]int{1, 2, 3}))
+
+	nonEmpty := filterEmpty([]string{"a", "", "b", ""})
+	println(len(nonEmpty))
+	for i, s := range nonEmpty {
+		println(i)
+		println(s)
+	}
+
+	counts := countWords([]string{"x", "y", "x", "z", "x"})
+	println(len(counts))
+	println(counts["x"])
+	println(counts["w"])
+	println(totalCount(counts))
+
+	groupValues()
+	countRunes()
+}
@@ -0,0 +1,64 @@
+package main
+
+func sumdiff(x, y int) (int, int) {
+	return x + y, x - y
+}
+
+func lookup(m map[string]int, key string) (int, bool) {
+	for k, v := range m {
+		if k == key {
+			return v, true
+		}
+	}
+	return 0, false
+}
+
+func splitFirst(s string) (string, string, bool) {
+	for i := range s {
+		if s[i:i+1] == "," {
+			return s[:i], s[i+1:], true
+		}
+	}
+	return s, "", false
+}
+
+func swap(x string, y string) (string, string) {
+	return y, x
+}
+
+func forward(x string, y string) (string, string) {
+	return swap(x, y)
+}
+
+func main() {
+	q, r := sumdiff(17, 5)
+	println(q)
+	println(r)
+
+	m := map[string]int{"a": 1, "b": 20}
+	v, ok := lookup(m, "b")
+	println(v)
+	println(ok)
+	_, ok = lookup(m, "c")
+	println(ok)
+
+	head, tail, found := splitFirst("x,y,z")
+	println(head)
+	println(tail)
+	println(found)
+	_, _, found = splitFirst("xyz")
+	println(found)
+
+	s1, s2 := forward("1", "2")
+	println(s1 + s2)
+
+	// Calls in a loop should not leak the stack values.
+	total := 0
+	i := 0
+	for i < 100 {
+		_, q2 := sumdiff(i, 10)
+		total = total + q2
+		i++
+	}
+	println(total)
+}
@@ -0,0 +1,82 @@
+package main
+
+import "strconv"
+
+func countUpper(s string) int {
+	n := 0
+	i := 0
+	for i < len(s) {
+		if s[i] >= 'A' && s[i] <= 'Z' {
+			n++
+		}
+		i++
+	}
+	return n
+}
+
+func isDigits(s string) bool {
+	for _, ch := range s {
+		if ch < '0' || ch > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+func ratio(x, y int) float64 {
+	return float64(x) / float64(y)
+}
+
+func formatFloat(f float64) string {
+	return strconv.FormatFloat(f, 'f', 3, 64)
+}
+
+func main() {
+	s := "Hello, World"
+	println(s[0])
+	println(s[len(s)-1] == 'd')
+	println(countUpper(s))
+	println(isDigits("12345"))
+	println(isDigits("12a45"))
+
+	b := byte(250)
+	b = b + 10
+	println(b)
+	b = b - 5
+	println(b)
+	println(int(b) + 300)
+
+	r := 'ж'
+	println(r)
+	println(string(r))
+	println(string(s[1]))
+	println(string(r) + string(rune(r+1)))
+	for i, ch := range "aé" {
+		println(i)
+		println(ch)
+	}
+
+	println(formatFloat(ratio(1, 3)))
+	println(ratio(3, 4) > 0.5)
+	println(ratio(1, 4) >= 0.5)
+	println(ratio(2, 4) == 0.5)
+	f := 1.5
+	f = f*2 - 0.25
+	println(formatFloat(f))
+	println(int(f))
+	println(int(0 - f))
+	println(byte(int(f) + 254))
+	println(formatFloat(float64(b) / 2))
+
+	n := 7
+	println(n * 6)
+	println(n / 2)
+	println((0 - n) / 2)
+	println(n * n / 3)
+	b = 100
+	println(b * 3)
+	println(b / 3)
+	r = 'a'
+	println(r * 2)
+	println(r / 2)
+}
@@ -0,0 +1,19 @@
+package main
+
+import "path/filepath"
+
+func main() {
+	matched, err := filepath.Match("*.go", "main.go")
+	println(matched)
+	println(err == nil)
+
+	matched, err = filepath.Match("*_test.go", "main.go")
+	println(matched)
+	println(err == nil)
+
+	matched, err = filepath.Match("a/?/c", "a/b/c")
+	println(matched)
+
+	_, err = filepath.Match("[", "x")
+	println(err.Error())
+}
@@ -0,0 +1,14 @@
+package main
+
+import "path"
+
+func main() {
+	println(path.Base("a/b/c.go"))
+	println(path.Base("a/b/"))
+	println(path.Base(""))
+	println(path.Base("/"))
+
+	println(path.Ext("a/b/c.go"))
+	println(path.Ext("a/b.tar.gz"))
+	println(path.Ext("a/b"))
+}
@@ -0,0 +1,24 @@
+package main
+
+import "regexp"
+
+func matchAll(pattern string, inputs []string) {
+	for _, s := range inputs {
+		matched, err := regexp.MatchString(pattern, s)
+		println(matched)
+		println(err == nil)
+	}
+}
+
+func main() {
+	matchAll(`^[a-z]+$`, []string{"foo", "Foo", ""})
+	// The same pattern is used again, it's taken from the cache.
+	matchAll(`^[a-z]+$`, []string{"bar"})
+	matchAll(`\d{2,}`, []string{"a1", "a12"})
+
+	_, err := regexp.MatchString(`(`, "x")
+	println(err.Error())
+	// Invalid patterns are cached too.
+	_, err = regexp.MatchString(`(`, "x")
+	println(err.Error())
+}
@@ -0,0 +1,19 @@
+package main
+
+import "sort"
+
+func main() {
+	words := []string{"c", "a", "b", "a"}
+	sort.Strings(words)
+	for _, w := range words {
+		println(w)
+	}
+
+	nums := []int{3, -1, 2}
+	sort.Ints(nums)
+	for _, x := range nums {
+		println(x)
+	}
+
+	sort.Strings([]string{})
+}
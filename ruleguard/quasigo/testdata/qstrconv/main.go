@@ -25,4 +25,11 @@ func main() {
 	if err2 == nil {
 		println("err2 is nil")
 	}
+
+	f, err3 := strconv.ParseFloat("2.5", 64)
+	println(err3 == nil)
+	println(strconv.FormatFloat(f, 'f', 2, 64))
+	println(strconv.FormatFloat(f*f, 'g', -1, 64))
+	_, err3 = strconv.ParseFloat("x", 64)
+	println(err3.Error())
 }
@@ -26,4 +26,32 @@ func main() {
 	println(strings.ReplaceAll("foo", "o", "f"))
 	println(strings.ReplaceAll(s, "l", "12"))
 	println(strings.ReplaceAll(s, "ll", ""))
+
+	parts := strings.Split("a,b,,c", ",")
+	println(len(parts))
+	for _, part := range parts {
+		println(part)
+	}
+	println(len(strings.Split("", ",")))
+
+	fields := strings.Fields("  foo bar\tbaz\n")
+	println(len(fields))
+	println(fields[2])
+	println(len(strings.Fields("   ")))
+
+	println(strings.ToLower(s))
+	println(strings.ToLower("ПРИВЕТ"))
+
+	println(strings.Index(s, "o"))
+	println(strings.Index(s, "$"))
+	println(strings.Index(s, ""))
+
+	println(strings.EqualFold("Go", "GO"))
+	println(strings.EqualFold("Go", "Goo"))
+
+	println(strings.Count("cheese", "e"))
+	println(strings.Count("five", ""))
+
+	println(strings.Repeat("ab", 3))
+	println(strings.Repeat("x", 0))
 }
@@ -0,0 +1,12 @@
+package main
+
+import "unicode"
+
+func main() {
+	for _, ch := range "aZ9ж_Д " {
+		println(unicode.IsUpper(ch))
+		println(unicode.IsLower(ch))
+		println(unicode.IsDigit(ch))
+		println(unicode.IsLetter(ch))
+	}
+}
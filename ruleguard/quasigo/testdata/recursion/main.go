@@ -0,0 +1,60 @@
+package main
+
+func fib(n int) int {
+	if n < 2 {
+		return n
+	}
+	return fib(n-1) + fib(n-2)
+}
+
+func countdown(n int) {
+	if n == 0 {
+		return
+	}
+	println(n)
+	countdown(n - 1)
+}
+
+func reverse(s string) string {
+	if len(s) == 0 {
+		return s
+	}
+	return reverse(s[1:]) + s[:1]
+}
+
+func isEven(n int) bool {
+	if n == 0 {
+		return true
+	}
+	if n == 1 {
+		return false
+	}
+	return isEven(n - 2)
+}
+
+func minmax(xs []int, i int) (int, int) {
+	if i == len(xs)-1 {
+		return xs[i], xs[i]
+	}
+	lo, hi := minmax(xs, i+1)
+	if xs[i] < lo {
+		lo = xs[i]
+	}
+	if xs[i] > hi {
+		hi = xs[i]
+	}
+	return lo, hi
+}
+
+func main() {
+	println(fib(0))
+	println(fib(1))
+	println(fib(15))
+	countdown(3)
+	println(reverse("hello"))
+	println(isEven(100))
+	println(isEven(77))
+	lo, hi := minmax([]int{5, -2, 9, 3}, 0)
+	println(lo)
+	println(hi)
+}
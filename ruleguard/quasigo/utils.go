@@ -4,8 +4,23 @@ import (
 	"encoding/binary"
 	"go/ast"
 	"go/types"
+	"sort"
 )
 
+// pickCallOp selects a call opcode that matches the sig results.
+func pickCallOp(sig *types.Signature, op, intOp, voidOp, multiOp opcode) opcode {
+	switch results := sig.Results(); {
+	case results.Len() == 0:
+		return voidOp
+	case results.Len() > 1:
+		return multiOp
+	case typeIsInt(results.At(0).Type()):
+		return intOp
+	default:
+		return op
+	}
+}
+
 func pickOp(cond bool, ifTrue, otherwise opcode) opcode {
 	if cond {
 		return ifTrue
@@ -21,19 +36,47 @@ func decode16(code []byte, pos int) int {
 	return int(int16(binary.LittleEndian.Uint16(code[pos:])))
 }
 
+// typeIsInt reports whether typ is one of the integer types
+// that are stored on the int stack: int, byte or rune.
 func typeIsInt(typ types.Type) bool {
 	basic, ok := typ.Underlying().(*types.Basic)
 	if !ok {
 		return false
 	}
 	switch basic.Kind() {
-	case types.Int, types.UntypedInt:
+	case types.Int, types.UntypedInt, types.Uint8, types.Int32, types.UntypedRune:
 		return true
 	default:
 		return false
 	}
 }
 
+func typeIsFloat(typ types.Type) bool {
+	basic, ok := typ.Underlying().(*types.Basic)
+	if !ok {
+		return false
+	}
+	switch basic.Kind() {
+	case types.Float64, types.UntypedFloat:
+		return true
+	default:
+		return false
+	}
+}
+
+func typeIsFunc(typ types.Type) bool {
+	_, ok := typ.Underlying().(*types.Signature)
+	return ok
+}
+
+func typeIsBool(typ types.Type) bool {
+	basic, ok := typ.Underlying().(*types.Basic)
+	if !ok {
+		return false
+	}
+	return basic.Info()&types.IsBoolean != 0
+}
+
 func typeIsString(typ types.Type) bool {
 	basic, ok := typ.Underlying().(*types.Basic)
 	if !ok {
@@ -58,3 +101,43 @@ func identName(n ast.Expr) string {
 	}
 	return ""
 }
+
+func maxInt(x, y int) int {
+	if x > y {
+		return x
+	}
+	return y
+}
+
+func sliceLen(x interface{}) int {
+	switch x := x.(type) {
+	case []interface{}:
+		return len(x)
+	case []string:
+		return len(x)
+	case []int:
+		return len(x)
+	default:
+		return 0
+	}
+}
+
+// mapKeys returns the sorted map keys,
+// so the map iteration order is deterministic.
+func mapKeys(x interface{}) []string {
+	var keys []string
+	switch m := x.(type) {
+	case map[string]interface{}:
+		keys = make([]string, 0, len(m))
+		for k := range m {
+			keys = append(keys, k)
+		}
+	case map[string]int:
+		keys = make([]string, 0, len(m))
+		for k := range m {
+			keys = append(keys, k)
+		}
+	}
+	sort.Strings(keys)
+	return keys
+}
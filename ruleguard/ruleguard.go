@@ -68,15 +68,57 @@ func (e *Engine) LoadedGroups() []GoRuleGroup {
 	return e.impl.LoadedGroups()
 }
 
+// HasPackageRules reports whether any of the loaded rules is package-scoped
+// (see dsl.Matcher.PackageWhere).
+//
+// Such rules are executed only by RunPackage(), Run() skips them.
+func (e *Engine) HasPackageRules() bool {
+	return e.impl.HasPackageRules()
+}
+
 // Run executes all loaded rules on a given file.
 // Matched rules invoke `RunContext.Report()` method.
 //
+// Package-scoped rules (see dsl.Matcher.PackageWhere) can't be executed
+// on a single file, so Run() silently skips them; use RunPackage() to execute them.
+// HasPackageRules() can be used to detect that case.
+//
 // Run() is thread-safe, unless used in parallel with Load(),
 // which modifies the engine state.
 func (e *Engine) Run(ctx *RunContext, f *ast.File) error {
 	return e.impl.Run(ctx, e.BuildContext, f)
 }
 
+// RunPackage is like Run(), but it executes the rules on all given files
+// of the ctx.Pkg package, including the package-scoped rules.
+//
+// Reports of the package-scoped rules are delivered after
+// all files are processed.
+func (e *Engine) RunPackage(ctx *RunContext, files []*ast.File) error {
+	return e.impl.RunPackage(ctx, e.BuildContext, files)
+}
+
+// CollectDiagnostics is like RunPackage(), but instead of calling the RunContext.Report()
+// it collects all reports from the given files as diagnostics.
+// The result is sorted with SortDiagnostics().
+//
+// The ctx.Report field is ignored.
+func (e *Engine) CollectDiagnostics(ctx *RunContext, files ...*ast.File) ([]Diagnostic, error) {
+	return e.impl.CollectDiagnostics(ctx, e.BuildContext, files)
+}
+
+// RunPackages loads the packages matching the patterns and executes
+// all loaded rules on their files.
+//
+// Unlike Run(), it takes care of the type checking and the parallel execution,
+// see RunPackagesOptions. All reports are collected and returned
+// in a stable order, RunContext.Report() is not used.
+//
+// Like Run(), it should not be used in parallel with Load().
+func (e *Engine) RunPackages(patterns []string, opts *RunPackagesOptions) (*RunPackagesResult, error) {
+	return e.impl.RunPackages(e.BuildContext, patterns, opts)
+}
+
 type LoadContext struct {
 	DebugFunc    string
 	DebugImports bool
@@ -118,9 +160,31 @@ type RunContext struct {
 
 	// Report is a function that is called for every successful ruleguard match.
 	// The pointer to ReportData is reused, it should not be kept.
-	// If you want to keep it after Report() returns, make a copy.
+	// If you want to keep it after Report() returns, make a copy
+	// or convert it to a Diagnostic with NewDiagnostic().
 	Report func(*ReportData)
 
+	// ReportUnusedSuppression is called for every //ruleguard:ignore directive
+	// that didn't suppress any report in the processed file.
+	//
+	// Directives that refer to the groups that are not loaded are never reported.
+	//
+	// If nil, unused directives are not reported.
+	ReportUnusedSuppression func(comment *ast.Comment)
+
+	// Stats collects the per-rule match statistics and timings.
+	//
+	// If nil, no stats are collected. Collecting the stats
+	// has a small overhead, so it's disabled by default.
+	Stats *RuleStats
+
+	// Fingerprints enables the ReportData.Fingerprint computation.
+	//
+	// If false, the Fingerprint field is always empty.
+	// Fingerprints require the reported node source text to be hashed
+	// for every report, so they're disabled by default.
+	Fingerprints bool
+
 	GoVersion GoVersion
 
 	// TruncateLen is a length threshold (in bytes) for interpolated vars in Report() templates.
@@ -150,11 +214,34 @@ type RunContext struct {
 }
 
 type ReportData struct {
-	RuleInfo   GoRuleInfo
-	Node       ast.Node
-	Message    string
+	RuleInfo GoRuleInfo
+	Node     ast.Node
+	Message  string
+
+	// Suggestion is a copy of the first element of Suggestions (if any).
+	// It's kept for the clients that can apply only one fix.
+	// It's allocated for every report, so it can be retained after Report() returns.
 	Suggestion *Suggestion
 
+	// Suggestions is a list of independent fixes for the reported issue.
+	// Like ReportData itself, this slice is reused between the Report() calls,
+	// so it should be copied if it's needed after Report() returns.
+	Suggestions []Suggestion
+
+	// Severity is a diagnostic level of the matched rule.
+	// It's inherited from the rule group, see GoRuleGroup.Severity.
+	Severity Severity
+
+	// Fingerprint identifies the reported issue without relying on its position.
+	// It's a hash of the reported node text with all whitespace normalized,
+	// so the fingerprint stays the same after the code is moved around.
+	//
+	// Note that fingerprints are not unique: identical code chunks
+	// reported by the same rule have identical fingerprints.
+	//
+	// It's only computed if RunContext.Fingerprints is set.
+	Fingerprint string
+
 	// Experimental: fields below are part of the experiment.
 	// They'll probably be removed or changed over time.
 
@@ -165,6 +252,23 @@ type Suggestion struct {
 	From        token.Pos
 	To          token.Pos
 	Replacement []byte
+
+	// Label is an optional fix description.
+	// It's set by the SuggestLabeled() DSL method.
+	Label string
+
+	// Edits are additional text edits that should be applied
+	// together with the main From-To replacement.
+	// They come from SuggestAt() calls and the missing imports.
+	Edits []TextEdit
+}
+
+// TextEdit replaces the From-To source range with Replacement.
+// From==To means insertion.
+type TextEdit struct {
+	From        token.Pos
+	To          token.Pos
+	Replacement []byte
 }
 
 type GoRuleInfo struct {
@@ -209,6 +313,12 @@ type GoRuleGroup struct {
 	// issue on the GitHub.
 	// Filled from the `doc:note` pragma content.
 	DocNote string
+
+	// Severity is a level that is assigned to all diagnostics
+	// reported by this group rules.
+	// Filled from the `doc:severity` pragma content.
+	// If pragma is absent, DefaultSeverity is used.
+	Severity Severity
 }
 
 // ImportError is returned when a ruleguard file references a package that cannot be imported.
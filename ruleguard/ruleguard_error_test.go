@@ -59,20 +59,20 @@ func TestParseFilterFuncError(t *testing.T) {
 			`can't compile new() builtin function call yet`,
 		},
 		{
-			`x := 5.6; return x != 0`,
-			`can't compile float constants yet`,
+			`x := float32(5.6); return x != 0`,
+			`can't compile float32 float constant`,
 		},
 		{
 			`s := ""; return s >= "a"`,
 			`>= is not implemented for string operands`,
 		},
 		{
-			`s := "foo"; b := s[0]; return b == 0`,
-			`can't compile *ast.IndexExpr yet`,
+			`x := i % 2; return x == 0`,
+			`can't compile binary % yet`,
 		},
 		{
 			`s := Foo{}; return s.X == 0`,
-			`can't compile *ast.CompositeLit yet`,
+			`can't compile struct{X int} composite literals yet`,
 		},
 
 		// Assignment errors.
@@ -96,28 +96,40 @@ func TestParseFilterFuncError(t *testing.T) {
 			`i++; return true`,
 			`can't assign to i, params are readonly`,
 		},
+		{
+			`xs := []int{1}; xs[0] = 2; return xs[0] == 2`,
+			`can't assign to []int elements yet`,
+		},
+		{
+			`n := 0; n = i; p := func() bool { return n == 1 }; return p()`,
+			`can't capture n: captured variables can't be reassigned`,
+		},
+		{
+			`m := map[string]interface{}{}; m["f"] = func() {}; return true`,
+			`can't use func value as interface{}`,
+		},
 
 		// Unsupported type errors.
 		{
-			`x := int32(0); return x == 0`,
-			`x local variable type: int32 is not supported, try something simpler`,
+			`x := int64(0); return x == 0`,
+			`x local variable type: int64 is not supported, try something simpler`,
 		},
 
 		// Implementation limits.
 		{
-			`x1:=1; x2:=x1; x3:=x2; x4:=x3; x5:=x4; x6:=x5; x7:=x6; x8:=x7; x9:=x8; return x9 == 1`,
-			`can't define x9: too many locals`,
+			`x1:=1; x2:=x1; x3:=x2; x4:=x3; x5:=x4; x6:=x5; x7:=x6; x8:=x7; x9:=x8; x10:=x9; x11:=x10; x12:=x11; x13:=x12; x14:=x13; x15:=x14; x16:=x15; x17:=x16; return x17 == 1`,
+			`can't define x17: too many locals`,
 		},
 	}
 
 	tests := []testCase{
 		{
-			`func f() int32 { return 0 }`,
-			`function result type: int32 is not supported, try something simpler`,
+			`func f() int64 { return 0 }`,
+			`function result type: int64 is not supported, try something simpler`,
 		},
 		{
-			`func f() []int { return nil }`,
-			`function result type: []int is not supported, try something simpler`,
+			`func f() []int64 { return nil }`,
+			`function result type: []int64 is not supported, try something simpler`,
 		},
 		{
 			`func f(s *string) int { return 0 }`,
@@ -134,8 +146,12 @@ func TestParseFilterFuncError(t *testing.T) {
 		},
 
 		{
-			`func f() (int, int) { return 0, 0 }`,
-			`multi-result functions are not supported`,
+			`func f() (int, int64) { return 0, 0 }`,
+			`function result type: int64 is not supported, try something simpler`,
+		},
+		{
+			`func f() func() { return nil }`,
+			`function result type: func() is not supported, try something simpler`,
 		},
 
 		{
@@ -220,6 +236,36 @@ func TestParseRuleError(t *testing.T) {
 			`\Qmissing Report(), Suggest() or Do() call`,
 		},
 
+		{
+			`m.Match("foo($x)").SuggestAt(m["y"], "$x")`,
+			`\Qmissing Report() call; it can't be inferred from SuggestAt()`,
+		},
+
+		{
+			`m.Match("foo($x)").Report("").SuggestAt(m["y"], "$x")`,
+			`\Qsuggestion refers to a non-existing var y`,
+		},
+
+		{
+			`m.Match("foo($x)").PackageWhere(m.Matches().Count > 1, m["y"]).Report("")`,
+			`\QPackageWhere() refers to a non-existing var y`,
+		},
+
+		{
+			`m.Match("foo($x)").PackageWhere(m["x"].Pure).Report("")`,
+			`\Qonly m.Matches() conditions can be used inside PackageWhere()`,
+		},
+
+		{
+			`m.Match("foo($x)").Where(m.Matches().Files > 1).Report("")`,
+			`\Qm.Matches() can only be used inside PackageWhere()`,
+		},
+
+		{
+			`m.Match("foo($x)").PackageWhere(true).PackageWhere(true).Report("")`,
+			`\QPackageWhere() can't be repeated`,
+		},
+
 		{
 			`m.MatchComment("").Do(doFunc)`,
 			`\Qcan't use Do() with MatchComment() yet`,
@@ -342,8 +388,8 @@ func TestParseFilterError(t *testing.T) {
 		},
 
 		{
-			`m["x"].Type.Is("interface{String() string}")`,
-			`parse type expr: can't convert interface{String() string} type expression`,
+			`m["x"].Type.Is("interface{~int}")`,
+			`parse type expr: can't convert interface{~int} type expression`,
 		},
 
 		{
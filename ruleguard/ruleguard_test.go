@@ -1,8 +1,16 @@
 package ruleguard
 
 import (
+	"bytes"
 	"go/ast"
+	"go/format"
+	"go/parser"
 	"go/token"
+	"go/types"
+	"path"
+	"runtime"
+	"sort"
+	"strings"
 	"testing"
 
 	"github.com/google/go-cmp/cmp"
@@ -144,3 +152,154 @@ func TestRenderMessage(t *testing.T) {
 		}
 	}
 }
+
+func TestReportSuggestionRetained(t *testing.T) {
+	rules := `
+	package gorules
+	import "github.com/quasilyte/go-ruleguard/dsl"
+	func testrule(m dsl.Matcher) {
+		m.Match("f($x)").Suggest("g($x)").Report("use g")
+	}`
+
+	e := NewEngine()
+	ctx := &LoadContext{
+		Fset: token.NewFileSet(),
+	}
+	if err := e.Load(ctx, "rules.go", strings.NewReader(rules)); err != nil {
+		t.Fatal(err)
+	}
+	runner, err := newDebugTestRunner("f(1); f(22)")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var suggestions []*Suggestion
+	runner.ctx.Report = func(data *ReportData) {
+		suggestions = append(suggestions, data.Suggestion)
+	}
+	if err := runner.Run(t, e); err != nil {
+		t.Fatal(err)
+	}
+
+	// The Suggestion pointers are kept after the Report() calls,
+	// so the next reports should not overwrite them.
+	var have []string
+	for _, s := range suggestions {
+		have = append(have, string(s.Replacement))
+	}
+	want := []string{"g(1)", "g(22)"}
+	if diff := cmp.Diff(want, have); diff != "" {
+		t.Errorf("suggestions mismatch (-want +have):\n%s", diff)
+	}
+}
+
+func TestSuggestImports(t *testing.T) {
+	rules := `
+	package gorules
+	import "github.com/quasilyte/go-ruleguard/dsl"
+	func testrule(m dsl.Matcher) {
+		m.Import("example.com/lib/xerrors")
+		m.Match("f($x)").Suggest("errors.New(strings.TrimSpace($x))")
+		m.Match("g($x)").Suggest("xerrors.New($x)")
+	}`
+
+	tests := []struct {
+		input string
+		want  string
+	}{
+		{
+			input: "package p\n\nfunc test() { f(\"\") }\n",
+			want:  "package p\n\nimport (\n\t\"errors\"\n\t\"strings\"\n)\n\nfunc test() { errors.New(strings.TrimSpace(\"\")) }\n",
+		},
+		{
+			input: "package p\n\nimport ()\n\nfunc test() { f(\"\") }\n",
+			want:  "package p\n\nimport (\n\t\"errors\"\n\t\"strings\"\n)\n\nfunc test() { errors.New(strings.TrimSpace(\"\")) }\n",
+		},
+		{
+			input: "package p\n\nimport (\"fmt\"; \"os\")\n\nfunc test() { f(\"\") }\n",
+			want:  "package p\n\nimport (\"errors\"; \"fmt\"; \"os\"\n\t\"strings\")\n\nfunc test() { errors.New(strings.TrimSpace(\"\")) }\n",
+		},
+		{
+			input: "package p\n\nimport (\n\t\"bytes\"\n\t\"fmt\"\n\n\t\"example.com/lib/x\"\n)\n\nfunc test() { f(\"\") }\n",
+			want:  "package p\n\nimport (\n\t\"bytes\"\n\t\"errors\"\n\t\"fmt\"\n\t\"strings\"\n\n\t\"example.com/lib/x\"\n)\n\nfunc test() { errors.New(strings.TrimSpace(\"\")) }\n",
+		},
+		{
+			input: "package p\n\nimport (\n\t// Doc comment.\n\t\"fmt\"\n)\n\nfunc test() { f(\"\") }\n",
+			want:  "package p\n\nimport (\n\t\"errors\"\n\t// Doc comment.\n\t\"fmt\"\n\t\"strings\"\n)\n\nfunc test() { errors.New(strings.TrimSpace(\"\")) }\n",
+		},
+		{
+			input: "package p\n\nimport (\n\t\"fmt\"\n\n\t\"example.com/lib/a\"\n\t\"example.com/lib/z\"\n)\n\nfunc test() { g(\"\") }\n",
+			want:  "package p\n\nimport (\n\t\"fmt\"\n\n\t\"example.com/lib/a\"\n\t\"example.com/lib/xerrors\"\n\t\"example.com/lib/z\"\n)\n\nfunc test() { xerrors.New(\"\") }\n",
+		},
+	}
+
+	e := NewEngine()
+	loadCtx := &LoadContext{
+		Fset: token.NewFileSet(),
+	}
+	if err := e.Load(loadCtx, "rules.go", strings.NewReader(rules)); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, test := range tests {
+		fset := token.NewFileSet()
+		f, err := parser.ParseFile(fset, "input.go", test.input, parser.ParseComments)
+		if err != nil {
+			t.Fatalf("parse %q: %v", test.input, err)
+		}
+		var typecheker types.Config
+		typecheker.Importer = fakeImporter{}
+		typecheker.Error = func(error) {} // Undefined f and g, unused imports
+		info := &types.Info{
+			Types: map[ast.Expr]types.TypeAndValue{},
+		}
+		pkg, _ := typecheker.Check("p", fset, []*ast.File{f}, info)
+		var edits []TextEdit
+		ctx := &RunContext{
+			Pkg:   pkg,
+			Types: info,
+			Sizes: types.SizesFor("gc", runtime.GOARCH),
+			Fset:  fset,
+			Report: func(data *ReportData) {
+				s := data.Suggestion
+				edits = append(edits, TextEdit{From: s.From, To: s.To, Replacement: s.Replacement})
+				edits = append(edits, s.Edits...)
+			},
+		}
+		if err := e.Run(ctx, f); err != nil {
+			t.Fatal(err)
+		}
+
+		sort.Slice(edits, func(i, j int) bool {
+			return edits[i].From > edits[j].From
+		})
+		have := []byte(test.input)
+		for _, edit := range edits {
+			from := fset.Position(edit.From).Offset
+			to := fset.Position(edit.To).Offset
+			have = append(have[:from:from], append(edit.Replacement, have[to:]...)...)
+		}
+		if diff := cmp.Diff(test.want, string(have)); diff != "" {
+			t.Errorf("input %q: result mismatch (-want +have):\n%s", test.input, diff)
+		}
+		// If the input is gofmt-clean, the result should be gofmt-clean too.
+		formattedInput, err := format.Source([]byte(test.input))
+		if err != nil {
+			t.Fatalf("input %q: format: %v", test.input, err)
+		}
+		formatted, err := format.Source(have)
+		if err != nil {
+			t.Fatalf("input %q: format result: %v", test.input, err)
+		}
+		if string(formattedInput) == test.input && !bytes.Equal(formatted, have) {
+			t.Errorf("input %q: result is not gofmt-clean:\n%s", test.input, have)
+		}
+	}
+}
+
+type fakeImporter struct{}
+
+func (fakeImporter) Import(pkgPath string) (*types.Package, error) {
+	pkg := types.NewPackage(pkgPath, path.Base(pkgPath))
+	pkg.MarkComplete()
+	return pkg, nil
+}
@@ -0,0 +1,201 @@
+package ruleguard
+
+import (
+	"errors"
+	"fmt"
+	"go/build"
+	"go/token"
+	"runtime"
+	"sort"
+	"sync"
+
+	"golang.org/x/tools/go/packages"
+)
+
+// RunPackagesOptions configures the Engine.RunPackages() call.
+// The zero value is a valid configuration.
+type RunPackagesOptions struct {
+	// Dir is a directory the packages patterns are resolved from.
+	// An empty string means the current working directory.
+	Dir string
+
+	// Env is the environment for the underlying go command.
+	// If nil, the current process environment is used.
+	Env []string
+
+	// BuildFlags are passed to the underlying go command, like "-tags=integration".
+	BuildFlags []string
+
+	// Tests enables the test files analysis.
+	Tests bool
+
+	// Workers is a max number of the packages being processed in parallel.
+	// If zero, runtime.GOMAXPROCS(0) is used.
+	Workers int
+
+	// GoVersion, TruncateLen and Fingerprints are passed to the RunContext as is.
+	GoVersion    GoVersion
+	TruncateLen  int
+	Fingerprints bool
+
+	// Stats is passed to the RunContext as is.
+	// If not nil, it collects the stats for all processed files.
+	Stats *RuleStats
+}
+
+// RunPackagesResult is a result of the Engine.RunPackages() call.
+type RunPackagesResult struct {
+	// Fset is a file set that contains all positions of the reports.
+	Fset *token.FileSet
+
+	// Reports are sorted by their position.
+	// Unlike the RunContext.Report() argument, these values
+	// are not reused and can be kept around.
+	Reports []ReportData
+}
+
+func (e *engine) RunPackages(buildContext *build.Context, patterns []string, opts *RunPackagesOptions) (*RunPackagesResult, error) {
+	if e.ruleSet == nil {
+		return nil, errors.New("used RunPackages() with an empty rule set; forgot to call Load() first?")
+	}
+	if opts == nil {
+		opts = &RunPackagesOptions{}
+	}
+
+	fset := token.NewFileSet()
+	cfg := &packages.Config{
+		Mode: packages.NeedName | packages.NeedFiles | packages.NeedCompiledGoFiles |
+			packages.NeedImports | packages.NeedTypes | packages.NeedTypesSizes |
+			packages.NeedSyntax | packages.NeedTypesInfo,
+		Dir:        opts.Dir,
+		Env:        opts.Env,
+		BuildFlags: opts.BuildFlags,
+		Tests:      opts.Tests,
+		Fset:       fset,
+	}
+	pkgs, err := packages.Load(cfg, patterns...)
+	if err != nil {
+		return nil, err
+	}
+
+	var loadErrors []error
+	for _, pkg := range pkgs {
+		for _, err := range pkg.Errors {
+			loadErrors = append(loadErrors, err)
+		}
+	}
+	if len(loadErrors) != 0 {
+		return nil, fmt.Errorf("load packages: %w", errors.Join(loadErrors...))
+	}
+
+	numWorkers := opts.Workers
+	if numWorkers <= 0 {
+		numWorkers = runtime.GOMAXPROCS(0)
+	}
+	if numWorkers > len(pkgs) {
+		numWorkers = len(pkgs)
+	}
+
+	type workerResult struct {
+		reports []ReportData
+		err     error
+	}
+	results := make([]workerResult, numWorkers)
+	queue := make(chan *packages.Package)
+	var wg sync.WaitGroup
+	wg.Add(numWorkers)
+	for i := 0; i < numWorkers; i++ {
+		go func(result *workerResult) {
+			defer wg.Done()
+			ctx := &RunContext{
+				Fset:         fset,
+				GoVersion:    opts.GoVersion,
+				TruncateLen:  opts.TruncateLen,
+				Fingerprints: opts.Fingerprints,
+				Stats:        opts.Stats,
+				State:        newRunnerState(e.state),
+				Report: func(data *ReportData) {
+					result.reports = append(result.reports, data.clone())
+				},
+			}
+			for pkg := range queue {
+				if result.err != nil {
+					continue // Drain the queue
+				}
+				ctx.Pkg = pkg.Types
+				ctx.Types = pkg.TypesInfo
+				ctx.Sizes = pkg.TypesSizes
+				result.err = e.RunPackage(ctx, buildContext, pkg.Syntax)
+			}
+		}(&results[i])
+	}
+	for _, pkg := range pkgs {
+		queue <- pkg
+	}
+	close(queue)
+	wg.Wait()
+
+	result := &RunPackagesResult{Fset: fset}
+	// With Tests=true, the same file can be a part of several packages.
+	// Such files are processed more than once, so we need to remove
+	// the duplicated reports.
+	type reportKey struct {
+		pos     token.Position
+		info    GoRuleInfo
+		message string
+	}
+	seen := make(map[reportKey]bool)
+	for _, r := range results {
+		if r.err != nil {
+			return nil, r.err
+		}
+		for _, data := range r.reports {
+			key := reportKey{
+				pos:     fset.Position(data.Node.Pos()),
+				info:    data.RuleInfo,
+				message: data.Message,
+			}
+			if seen[key] {
+				continue
+			}
+			seen[key] = true
+			result.Reports = append(result.Reports, data)
+		}
+	}
+	sortReports(fset, result.Reports)
+
+	return result, nil
+}
+
+// clone returns a deep copy of the report data.
+func (data *ReportData) clone() ReportData {
+	cloned := *data
+	cloned.Suggestion = nil
+	cloned.Suggestions = nil
+	if len(data.Suggestions) != 0 {
+		cloned.Suggestions = make([]Suggestion, len(data.Suggestions))
+		copy(cloned.Suggestions, data.Suggestions)
+		cloned.Suggestion = &cloned.Suggestions[0]
+	}
+	return cloned
+}
+
+func sortReports(fset *token.FileSet, reports []ReportData) {
+	sort.SliceStable(reports, func(i, j int) bool {
+		x := fset.Position(reports[i].Node.Pos())
+		y := fset.Position(reports[j].Node.Pos())
+		if x.Filename != y.Filename {
+			return x.Filename < y.Filename
+		}
+		if x.Offset != y.Offset {
+			return x.Offset < y.Offset
+		}
+		// Reports of different rules for the same node.
+		gx := reports[i].RuleInfo
+		gy := reports[j].RuleInfo
+		if gx.Group.Name != gy.Group.Name {
+			return gx.Group.Name < gy.Group.Name
+		}
+		return gx.Line < gy.Line
+	})
+}
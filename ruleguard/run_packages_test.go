@@ -0,0 +1,78 @@
+package ruleguard
+
+import (
+	"fmt"
+	"go/token"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestRunPackages(t *testing.T) {
+	src := `
+	package gorules
+
+	import "github.com/quasilyte/go-ruleguard/dsl"
+
+	func sloppyLen(m dsl.Matcher) {
+		m.Match("len($_) >= 0").Report("$$ is always true")
+	}
+
+	func dupSubExpr(m dsl.Matcher) {
+		m.Match("$x || $x", "$x == $x").
+			Where(m["x"].Pure).
+			Report("suspicious identical LHS and RHS")
+	}
+	`
+
+	e := NewEngine()
+	if err := e.Load(&LoadContext{Fset: token.NewFileSet()}, "rules.go", strings.NewReader(src)); err != nil {
+		t.Fatalf("load error: %v", err)
+	}
+
+	for _, workers := range []int{0, 1, 4} {
+		result, err := e.RunPackages([]string{"."}, &RunPackagesOptions{
+			Dir:     filepath.Join("testdata", "runpackages"),
+			Workers: workers,
+		})
+		if err != nil {
+			t.Fatalf("workers=%d: run error: %v", workers, err)
+		}
+		var have []string
+		for _, r := range result.Reports {
+			pos := result.Fset.Position(r.Node.Pos())
+			have = append(have, fmt.Sprintf("%s:%d: %s: %s",
+				filepath.Base(pos.Filename), pos.Line, r.RuleInfo.Group.Name, r.Message))
+		}
+		want := []string{
+			`a.go:4: dupSubExpr: suspicious identical LHS and RHS`,
+			`a.go:7: sloppyLen: len("a") >= 0 is always true`,
+			`b.go:4: sloppyLen: len(s) >= 0 is always true`,
+			`b.go:4: sloppyLen: len(s) >= 0 is always true`,
+		}
+		if diff := cmp.Diff(want, have); diff != "" {
+			t.Errorf("workers=%d: reports mismatch (-want +have):\n%s", workers, diff)
+		}
+	}
+}
+
+func TestRunPackagesLoadError(t *testing.T) {
+	e := NewEngine()
+	src := `
+	package gorules
+	import "github.com/quasilyte/go-ruleguard/dsl"
+	func f(m dsl.Matcher) { m.Match("println($x)").Report("") }
+	`
+	if _, err := e.RunPackages([]string{"."}, nil); err == nil || !strings.Contains(err.Error(), "empty rule set") {
+		t.Fatalf("expected an empty rule set error, have %v", err)
+	}
+	if err := e.Load(&LoadContext{Fset: token.NewFileSet()}, "rules.go", strings.NewReader(src)); err != nil {
+		t.Fatalf("load error: %v", err)
+	}
+	_, err := e.RunPackages([]string{"./testdata/no_such_dir"}, nil)
+	if err == nil {
+		t.Fatalf("expected a load error")
+	}
+}
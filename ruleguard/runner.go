@@ -8,16 +8,18 @@ import (
 	"go/build"
 	"go/printer"
 	"go/token"
+	"hash/fnv"
 	"os"
+	"path"
 	"path/filepath"
 	"reflect"
 	"sort"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/quasilyte/go-ruleguard/ruleguard/goutil"
 	"github.com/quasilyte/go-ruleguard/ruleguard/profiling"
-	"github.com/quasilyte/go-ruleguard/ruleguard/quasigo"
 	"github.com/quasilyte/go-ruleguard/ruleguard/typematch"
 	"github.com/quasilyte/gogrep"
 	"github.com/quasilyte/gogrep/nodetag"
@@ -40,6 +42,7 @@ type rulesRunner struct {
 
 	importer *goImporter
 
+	file     *ast.File
 	filename string
 	src      []byte
 
@@ -58,6 +61,17 @@ type rulesRunner struct {
 	// In those cases we need a more complicated algorithm.
 	nodePath *nodePath
 
+	// suppressions are the ruleguard:ignore directives of the current file.
+	suppressions []suppression
+
+	// stats are collected for the current file only if RunContext.Stats is set.
+	// They're merged into the RunContext.Stats after the file is processed.
+	stats map[ruleStatsKey]*RuleStat
+
+	// pkgMatches is set only during the Engine.RunPackage() execution.
+	// When it's nil, the package-scoped rules are skipped.
+	pkgMatches *packageMatches
+
 	filterParams filterParams
 }
 
@@ -119,6 +133,9 @@ func newRulesRunner(ctx *RunContext, buildContext *build.Context, state *engineS
 	}
 
 	evalEnv.Stack.Push(&rr.filterParams)
+	if ctx.Stats != nil {
+		rr.stats = make(map[ruleStatsKey]*RuleStat)
+	}
 	if ctx.TruncateLen == 0 {
 		rr.truncateLen = 60
 	}
@@ -160,6 +177,27 @@ func (rr *rulesRunner) nodeText(n ast.Node) []byte {
 	return buf.Bytes()
 }
 
+// fingerprint returns a whitespace-insensitive hash of the node text.
+func (rr *rulesRunner) fingerprint(n ast.Node) string {
+	h := fnv.New64a()
+	for i, field := range bytes.Fields(rr.nodeText(n)) {
+		if i != 0 {
+			h.Write([]byte{' '})
+		}
+		h.Write(field)
+	}
+	return fmt.Sprintf("%016x", h.Sum64())
+}
+
+// reportFingerprint returns a ReportData.Fingerprint value for n.
+// It's empty unless fingerprints are requested by the RunContext.
+func (rr *rulesRunner) reportFingerprint(n ast.Node) string {
+	if !rr.ctx.Fingerprints {
+		return ""
+	}
+	return rr.fingerprint(n)
+}
+
 func (rr *rulesRunner) fileBytes() []byte {
 	if rr.src != nil {
 		return rr.src
@@ -184,9 +222,14 @@ func (rr *rulesRunner) run(f *ast.File) error {
 		panic("internal error: node path is not empty")
 	}
 
+	rr.file = f
 	rr.filename = rr.ctx.Fset.Position(f.Pos()).Filename
 	rr.filterParams.filename = rr.filename
 	rr.collectImports(f)
+	rr.collectSuppressions(f)
+	if rr.pkgMatches != nil {
+		rr.pkgMatches.addFile(rr.suppressions)
+	}
 
 	if rr.rules.universal.categorizedNum != 0 {
 		var inspector astWalker
@@ -205,6 +248,16 @@ func (rr *rulesRunner) run(f *ast.File) error {
 		}
 	}
 
+	// For the RunPackage(), the unused directives are reported
+	// after the package-scoped matches are processed.
+	if rr.ctx.ReportUnusedSuppression != nil && rr.pkgMatches == nil {
+		reportUnusedSuppressions(rr.ctx, rr.rules, rr.suppressions)
+	}
+
+	if rr.stats != nil {
+		rr.ctx.Stats.merge(rr.stats)
+	}
+
 	return nil
 }
 
@@ -213,58 +266,77 @@ func (rr *rulesRunner) runCommentRules(comment *ast.Comment) {
 	file := rr.ctx.Fset.File(comment.Pos())
 
 	for _, rule := range rr.rules.universal.commentRules {
-		var m matchData
-		if rule.captureGroups {
-			result := rule.pat.FindStringSubmatchIndex(comment.Text)
-			if result == nil {
+		if rule.base.pkgScope != nil && rr.pkgMatches == nil {
+			continue
+		}
+		var start time.Time
+		if rr.stats != nil {
+			start = time.Now()
+		}
+		accept := rr.runCommentRule(rule, comment, file)
+		if rr.stats != nil {
+			st := rr.ruleStat(rule.base.group, rule.base.line)
+			st.Candidates++
+			st.Time += time.Since(start)
+		}
+		if accept {
+			break
+		}
+	}
+}
+
+func (rr *rulesRunner) runCommentRule(rule goCommentRule, comment *ast.Comment, file *token.File) bool {
+	var m matchData
+	if rule.captureGroups {
+		result := rule.pat.FindStringSubmatchIndex(comment.Text)
+		if result == nil {
+			return false
+		}
+		for i, name := range rule.pat.SubexpNames() {
+			if i == 0 || name == "" {
 				continue
 			}
-			for i, name := range rule.pat.SubexpNames() {
-				if i == 0 || name == "" {
-					continue
-				}
-				resultIndex := i * 2
-				beginPos := result[resultIndex+0]
-				endPos := result[resultIndex+1]
-				// Negative index a special case when named group captured nothing.
-				// Consider this pattern: `(?P<x>foo)|(bar)`.
-				// If we have `bar` input string, <x> will remain empty.
-				if beginPos < 0 || endPos < 0 {
-					m.match.Capture = append(m.match.Capture, gogrep.CapturedNode{
-						Name: name,
-						Node: &ast.Comment{Slash: comment.Pos()},
-					})
-					continue
-				}
+			resultIndex := i * 2
+			beginPos := result[resultIndex+0]
+			endPos := result[resultIndex+1]
+			// Negative index a special case when named group captured nothing.
+			// Consider this pattern: `(?P<x>foo)|(bar)`.
+			// If we have `bar` input string, <x> will remain empty.
+			if beginPos < 0 || endPos < 0 {
 				m.match.Capture = append(m.match.Capture, gogrep.CapturedNode{
 					Name: name,
-					Node: &ast.Comment{
-						Slash: file.Pos(beginPos + file.Offset(comment.Pos())),
-						Text:  comment.Text[beginPos:endPos],
-					},
+					Node: &ast.Comment{Slash: comment.Pos()},
 				})
-			}
-			m.match.Node = &ast.Comment{
-				Slash: file.Pos(result[0] + file.Offset(comment.Pos())),
-				Text:  comment.Text[result[0]:result[1]],
-			}
-		} else {
-			// Fast path: no need to save any submatches.
-			result := rule.pat.FindStringIndex(comment.Text)
-			if result == nil {
 				continue
 			}
-			m.match.Node = &ast.Comment{
-				Slash: file.Pos(result[0] + file.Offset(comment.Pos())),
-				Text:  comment.Text[result[0]:result[1]],
-			}
+			m.match.Capture = append(m.match.Capture, gogrep.CapturedNode{
+				Name: name,
+				Node: &ast.Comment{
+					Slash: file.Pos(beginPos + file.Offset(comment.Pos())),
+					Text:  comment.Text[beginPos:endPos],
+				},
+			})
 		}
-
-		accept := rr.handleCommentMatch(rule, m)
-		if accept {
-			break
+		m.match.Node = &ast.Comment{
+			Slash: file.Pos(result[0] + file.Offset(comment.Pos())),
+			Text:  comment.Text[result[0]:result[1]],
+		}
+	} else {
+		// Fast path: no need to save any submatches.
+		result := rule.pat.FindStringIndex(comment.Text)
+		if result == nil {
+			return false
 		}
+		m.match.Node = &ast.Comment{
+			Slash: file.Pos(result[0] + file.Offset(comment.Pos())),
+			Text:  comment.Text[result[0]:result[1]],
+		}
+	}
+
+	if rr.stats != nil {
+		rr.ruleStat(rule.base.group, rule.base.line).Matches++
 	}
+	return rr.handleCommentMatch(rule, m)
 }
 
 func (rr *rulesRunner) runRules(n ast.Node, tag nodetag.Value) {
@@ -273,15 +345,32 @@ func (rr *rulesRunner) runRules(n ast.Node, tag nodetag.Value) {
 	// To enable labels, use "-tags pproflabels" build tag.
 
 	for _, rule := range rr.rules.universal.rulesByTag[tag] {
+		if rule.pkgScope != nil && rr.pkgMatches == nil {
+			continue
+		}
 		if profiling.LabelsEnabled {
 			profiling.EnterWithLabels(rr.bgContext, rule.group.Name)
 		}
 
+		var start time.Time
+		if rr.stats != nil {
+			start = time.Now()
+		}
+
 		matched := false
 		rule.pat.MatchNode(&rr.gogrepState, n, func(m gogrep.MatchData) {
+			if rr.stats != nil {
+				rr.ruleStat(rule.group, rule.line).Matches++
+			}
 			matched = rr.handleMatch(rule, m)
 		})
 
+		if rr.stats != nil {
+			st := rr.ruleStat(rule.group, rule.line)
+			st.Candidates++
+			st.Time += time.Since(start)
+		}
+
 		if profiling.LabelsEnabled {
 			profiling.Leave(rr.bgContext)
 		}
@@ -293,6 +382,8 @@ func (rr *rulesRunner) runRules(n ast.Node, tag nodetag.Value) {
 }
 
 func (rr *rulesRunner) reject(rule goRule, reason string, m matchData) {
+	rr.recordReject(rule.group, rule.line, reason)
+
 	if rule.group.Name != rr.ctx.Debug {
 		return // This rule is not being debugged
 	}
@@ -352,13 +443,9 @@ func (rr *rulesRunner) handleCommentMatch(rule goCommentRule, m matchData) bool
 	if rule.base.location != "" {
 		node, _ = m.CapturedByName(rule.base.location)
 	}
-	var suggestion *Suggestion
-	if rule.base.suggestion != "" {
-		suggestion = &Suggestion{
-			Replacement: []byte(rr.renderMessage(rule.base.suggestion, m, false)),
-			From:        node.Pos(),
-			To:          node.End(),
-		}
+	rr.resetSuggestions()
+	for _, s := range rule.base.suggestions {
+		rr.addRuleSuggestion(node, s, m)
 	}
 	info := GoRuleInfo{
 		Group: rule.base.group,
@@ -367,10 +454,10 @@ func (rr *rulesRunner) handleCommentMatch(rule goCommentRule, m matchData) bool
 	rr.reportData.RuleInfo = info
 	rr.reportData.Node = node
 	rr.reportData.Message = message
-	rr.reportData.Suggestion = suggestion
+	rr.reportData.Severity = rule.base.group.Severity
+	rr.reportData.Fingerprint = rr.reportFingerprint(node)
 
-	rr.ctx.Report(&rr.reportData)
-	return true
+	return rr.emitReport(rule.base, node, m)
 }
 
 func (rr *rulesRunner) handleMatch(rule goRule, m gogrep.MatchData) bool {
@@ -391,12 +478,16 @@ func (rr *rulesRunner) handleMatch(rule goRule, m gogrep.MatchData) bool {
 		node, _ = m.CapturedByName(rule.location)
 	}
 
+	rr.resetSuggestions()
+
 	var messageText string
-	var suggestText string
 	if rule.do != nil {
 		rr.filterParams.reportString = ""
 		rr.filterParams.suggestString = ""
-		_ = quasigo.Call(rr.filterParams.env, rule.do)
+		if _, err := callUserFunc(rr.filterParams.env, rule.do); err != nil {
+			rr.reject(rule, "Do: "+err.Error(), matchData{match: m})
+			return false
+		}
 		messageText = rr.filterParams.reportString
 		if messageText == "" {
 			if rr.filterParams.suggestString != "" {
@@ -406,21 +497,12 @@ func (rr *rulesRunner) handleMatch(rule goRule, m gogrep.MatchData) bool {
 			}
 		}
 		if rr.filterParams.suggestString != "" {
-			suggestText = rr.filterParams.suggestString
+			rr.addSuggestion(node, "", rr.filterParams.suggestString)
 		}
 	} else {
 		messageText = rr.renderMessage(rule.msg, matchData{match: m}, true)
-		if rule.suggestion != "" {
-			suggestText = rr.renderMessage(rule.suggestion, matchData{match: m}, false)
-		}
-	}
-
-	var suggestion *Suggestion
-	if suggestText != "" {
-		suggestion = &Suggestion{
-			Replacement: []byte(suggestText),
-			From:        node.Pos(),
-			To:          node.End(),
+		for _, s := range rule.suggestions {
+			rr.addRuleSuggestion(node, s, matchData{match: m})
 		}
 	}
 
@@ -431,14 +513,277 @@ func (rr *rulesRunner) handleMatch(rule goRule, m gogrep.MatchData) bool {
 	rr.reportData.RuleInfo = info
 	rr.reportData.Node = node
 	rr.reportData.Message = messageText
-	rr.reportData.Suggestion = suggestion
+	rr.reportData.Severity = rule.group.Severity
+	rr.reportData.Fingerprint = rr.reportFingerprint(node)
 
 	rr.reportData.Func = rr.filterParams.currentFunc
 
+	return rr.emitReport(rule, node, matchData{match: m})
+}
+
+// emitReport reports the rr.reportData unless it's suppressed.
+// For the package-scoped rules, the report is saved
+// until the entire package is processed; the suppressions
+// are applied to them only if they're reported.
+//
+// It returns false for the package-scoped rules: their matches
+// may never be reported, so they shouldn't prevent other rules
+// from matching the same node.
+func (rr *rulesRunner) emitReport(rule goRule, node ast.Node, m matchData) bool {
+	if rule.pkgScope != nil {
+		key := rr.packageMatchKey(rule.pkgScope, m)
+		rr.pkgMatches.add(rule.pkgScope, key, rr.filename, &rr.reportData)
+		return false
+	}
+	if rr.isSuppressed(rule.group, node) {
+		return true
+	}
+	rr.recordReport(rule.group, rule.line)
 	rr.ctx.Report(&rr.reportData)
 	return true
 }
 
+func (rr *rulesRunner) resetSuggestions() {
+	rr.reportData.Suggestion = nil
+	rr.reportData.Suggestions = rr.reportData.Suggestions[:0]
+}
+
+func (rr *rulesRunner) addSuggestion(node ast.Node, label, replacement string) {
+	rr.appendSuggestion(Suggestion{
+		Replacement: []byte(replacement),
+		From:        node.Pos(),
+		To:          node.End(),
+		Label:       label,
+	})
+}
+
+func (rr *rulesRunner) appendSuggestion(s Suggestion) {
+	rr.reportData.Suggestions = append(rr.reportData.Suggestions, s)
+	if rr.reportData.Suggestion == nil {
+		// Suggestions slice is reused between the reports,
+		// but the Report() callback is allowed to keep the Suggestion pointer.
+		first := s
+		rr.reportData.Suggestion = &first
+	}
+}
+
+func (rr *rulesRunner) addRuleSuggestion(node ast.Node, s goRuleSuggestion, m matchData) {
+	var edits []TextEdit
+	if s.template != "" {
+		edits = append(edits, TextEdit{
+			From:        node.Pos(),
+			To:          node.End(),
+			Replacement: []byte(rr.renderMessage(s.template, m, false)),
+		})
+	}
+	for _, e := range s.edits {
+		n, ok := m.CapturedByName(e.location)
+		if !ok {
+			continue
+		}
+		edits = append(edits, TextEdit{
+			From:        n.Pos(),
+			To:          n.End(),
+			Replacement: []byte(rr.renderMessage(e.template, m, false)),
+		})
+	}
+	if len(edits) == 0 {
+		return
+	}
+	edits = append(edits, rr.importsEdits(node.Pos(), s.imports)...)
+
+	rr.appendSuggestion(Suggestion{
+		From:        edits[0].From,
+		To:          edits[0].To,
+		Replacement: edits[0].Replacement,
+		Label:       s.label,
+		Edits:       edits[1:],
+	})
+}
+
+// importsEdits creates edits that add all missing imports to the current file.
+// Packages that can't be imported without a name conflict are skipped.
+func (rr *rulesRunner) importsEdits(pos token.Pos, imports []goRuleSuggestionImport) []TextEdit {
+	var missing []goRuleSuggestionImport
+	for _, imported := range imports {
+		if _, ok := rr.filterParams.imports[imported.path]; ok {
+			continue
+		}
+		if rr.ctx.Pkg != nil {
+			scope := rr.ctx.Pkg.Scope().Innermost(pos)
+			if scope != nil {
+				if _, obj := scope.LookupParent(imported.name, pos); obj != nil {
+					continue // Would shadow (or conflict with) some other symbol
+				}
+			}
+		}
+		missing = append(missing, imported)
+	}
+	if len(missing) == 0 {
+		return nil
+	}
+	sort.SliceStable(missing, func(i, j int) bool {
+		return missing[i].path < missing[j].path
+	})
+
+	// Insert the specs into the first parenthesized import decl, if any.
+	// Otherwise, insert a new import decl after the package clause.
+	for _, decl := range rr.file.Decls {
+		decl, ok := decl.(*ast.GenDecl)
+		if !ok || decl.Tok != token.IMPORT {
+			break
+		}
+		if !decl.Lparen.IsValid() {
+			continue
+		}
+		if len(decl.Specs) == 0 {
+			var buf strings.Builder
+			for _, imported := range missing {
+				buf.WriteString("\n\t" + importSpecText(imported))
+			}
+			buf.WriteString("\n")
+			pos := decl.Lparen + 1
+			return []TextEdit{{From: pos, To: pos, Replacement: []byte(buf.String())}}
+		}
+		return rr.importSpecsEdits(decl, missing)
+	}
+	var buf strings.Builder
+	buf.WriteString("\n\nimport ")
+	if len(missing) == 1 {
+		buf.WriteString(importSpecText(missing[0]))
+	} else {
+		buf.WriteString("(\n")
+		for _, imported := range missing {
+			buf.WriteString("\t" + importSpecText(imported) + "\n")
+		}
+		buf.WriteString(")")
+	}
+	end := rr.file.Name.End()
+	return []TextEdit{{From: end, To: end, Replacement: []byte(buf.String())}}
+}
+
+// importSpecsEdits inserts the missing imports into the non-empty parenthesized import decl.
+//
+// Like astutil.AddImport, it picks the import group that has a spec
+// with the longest shared path prefix. The new spec is inserted
+// into that group in the sorted position, so the result stays gofmt-clean.
+func (rr *rulesRunner) importSpecsEdits(decl *ast.GenDecl, missing []goRuleSuggestionImport) []TextEdit {
+	tokFile := rr.ctx.Fset.File(decl.Pos())
+	line := func(pos token.Pos) int { return tokFile.Line(pos) }
+	specs := decl.Specs
+	specStart := func(i int) token.Pos {
+		spec := specs[i].(*ast.ImportSpec)
+		if spec.Doc != nil {
+			return spec.Doc.Pos()
+		}
+		return spec.Pos()
+	}
+	// Groups are separated by the blank lines.
+	sameGroup := func(i int) bool {
+		return line(specStart(i)) <= line(specs[i-1].End())+1
+	}
+
+	// Several imports can be inserted at the same position;
+	// they're sorted, so the replacements are concatenated in order.
+	var edits []TextEdit
+	addEdit := func(pos token.Pos, text string) {
+		for i := range edits {
+			if edits[i].From == pos {
+				edits[i].Replacement = append(edits[i].Replacement, text...)
+				return
+			}
+		}
+		edits = append(edits, TextEdit{From: pos, To: pos, Replacement: []byte(text)})
+	}
+
+	for _, imported := range missing {
+		best := bestImportSpec(specs, imported.path)
+		begin, end := best, best+1
+		for begin > 0 && sameGroup(begin) {
+			begin--
+		}
+		for end < len(specs) && sameGroup(end) {
+			end++
+		}
+		i := begin
+		for i < end && importSpecPath(specs[i]) <= imported.path {
+			i++
+		}
+		text := importSpecText(imported)
+		switch {
+		case i == end:
+			addEdit(specs[end-1].End(), "\n\t"+text)
+		case line(specStart(i)) == line(decl.Lparen) || (i > begin && line(specStart(i)) == line(specs[i-1].End())):
+			// Specs are separated by semicolons, like in `import ("fmt"; "os")`.
+			addEdit(specStart(i), text+"; ")
+		default:
+			addEdit(tokFile.LineStart(line(specStart(i))), "\t"+text+"\n")
+		}
+	}
+	sort.Slice(edits, func(i, j int) bool {
+		return edits[i].From < edits[j].From
+	})
+	return edits
+}
+
+// bestImportSpec returns an index of the spec that is the best
+// neighbour for the importPath, using the astutil.AddImport heuristics:
+// the longest shared path prefix wins; if there is no shared prefix,
+// stdlib packages go to the first spec and the third-party packages
+// go to the first third-party spec.
+func bestImportSpec(specs []ast.Spec, importPath string) int {
+	thirdParty := isThirdPartyImport(importPath)
+	best := 0
+	bestMatch := -1
+	seenAnyThirdParty := false
+	for i, spec := range specs {
+		p := importSpecPath(spec)
+		n := importPathMatchLen(p, importPath)
+		if n > bestMatch || (bestMatch == 0 && !seenAnyThirdParty && thirdParty) {
+			best = i
+			bestMatch = n
+		}
+		seenAnyThirdParty = seenAnyThirdParty || isThirdPartyImport(p)
+	}
+	return best
+}
+
+func importSpecPath(spec ast.Spec) string {
+	s, err := strconv.Unquote(spec.(*ast.ImportSpec).Path.Value)
+	if err != nil {
+		return ""
+	}
+	return s
+}
+
+func importSpecText(imported goRuleSuggestionImport) string {
+	spec := strconv.Quote(imported.path)
+	if imported.name != path.Base(imported.path) {
+		spec = imported.name + " " + spec
+	}
+	return spec
+}
+
+// importPathMatchLen returns the number of the shared path segments.
+func importPathMatchLen(x, y string) int {
+	n := 0
+	for i := 0; i < len(x) && i < len(y) && x[i] == y[i]; i++ {
+		if x[i] == '/' {
+			n++
+		}
+	}
+	return n
+}
+
+func isThirdPartyImport(importPath string) bool {
+	// Third party package import path usually contains "." (".com", ".org", ...).
+	first := importPath
+	if i := strings.IndexByte(importPath, '/'); i != -1 {
+		first = importPath[:i]
+	}
+	return strings.Contains(first, ".")
+}
+
 func (rr *rulesRunner) collectImports(f *ast.File) {
 	rr.filterParams.imports = make(map[string]struct{}, len(f.Imports))
 	for _, spec := range f.Imports {
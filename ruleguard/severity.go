@@ -0,0 +1,50 @@
+package ruleguard
+
+import (
+	"fmt"
+)
+
+// Severity describes how important the reported issue is.
+//
+// Severities are ordered, so they can be compared:
+// SeverityInfo < SeverityWarning < SeverityError.
+type Severity int
+
+const (
+	SeverityInfo Severity = iota
+	SeverityWarning
+	SeverityError
+)
+
+// DefaultSeverity is used for rule groups that have no `doc:severity` pragma.
+const DefaultSeverity = SeverityWarning
+
+func (s Severity) String() string {
+	switch s {
+	case SeverityInfo:
+		return "info"
+	case SeverityWarning:
+		return "warning"
+	case SeverityError:
+		return "error"
+	default:
+		return fmt.Sprintf("Severity(%d)", int(s))
+	}
+}
+
+// ParseSeverity converts a severity name into a Severity value.
+// An empty string is interpreted as DefaultSeverity.
+func ParseSeverity(s string) (Severity, error) {
+	switch s {
+	case "":
+		return DefaultSeverity, nil
+	case "info":
+		return SeverityInfo, nil
+	case "warning":
+		return SeverityWarning, nil
+	case "error":
+		return SeverityError, nil
+	default:
+		return DefaultSeverity, fmt.Errorf("unknown severity %q", s)
+	}
+}
@@ -0,0 +1,187 @@
+package ruleguard
+
+import (
+	"fmt"
+	"io"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"text/tabwriter"
+	"time"
+)
+
+// RuleStats collects the per-rule execution statistics.
+//
+// Assign it to the RunContext.Stats to enable the stats collection.
+// The same object can be shared between several RunContext objects,
+// it's safe for concurrent use.
+type RuleStats struct {
+	mu    sync.Mutex
+	rules map[ruleStatsKey]*RuleStat
+}
+
+// RuleStat describes how a single rule performed.
+type RuleStat struct {
+	// Group and Line identify the rule, like in GoRuleInfo.
+	Group *GoRuleGroup
+	Line  int
+
+	// Candidates is a number of nodes the rule pattern was tried against.
+	// For the comment rules, it's a number of the comments checked.
+	Candidates int
+
+	// Matches is a number of pattern matches, before the filters are applied.
+	Matches int
+
+	// Rejects maps a filter reject reason to the number of matches
+	// that were rejected because of it.
+	Rejects map[string]int
+
+	// Reports is a number of the reported issues.
+	// Matches that were suppressed by the ignore directives are not counted.
+	Reports int
+
+	// Time is a total time spent on this rule,
+	// including the pattern matching, filters and reporting.
+	Time time.Duration
+}
+
+// NewRuleStats returns an empty stats collector.
+func NewRuleStats() *RuleStats {
+	return &RuleStats{rules: make(map[ruleStatsKey]*RuleStat)}
+}
+
+// List returns the collected stats sorted by the rule location.
+// The result is a copy and can be modified by the caller.
+func (s *RuleStats) List() []RuleStat {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	list := make([]RuleStat, 0, len(s.rules))
+	for _, st := range s.rules {
+		copied := *st
+		copied.Rejects = make(map[string]int, len(st.Rejects))
+		for reason, n := range st.Rejects {
+			copied.Rejects[reason] = n
+		}
+		list = append(list, copied)
+	}
+	sort.Slice(list, func(i, j int) bool {
+		x := list[i]
+		y := list[j]
+		if x.Group.Filename != y.Group.Filename {
+			return x.Group.Filename < y.Group.Filename
+		}
+		return x.Line < y.Line
+	})
+	return list
+}
+
+// WriteTable prints the collected stats as a text table.
+//
+// Rules are ordered by the time spent on them, so the slowest
+// rules come first. Every rule row is followed by its filter
+// reject reasons, the most frequent reasons come first.
+func (s *RuleStats) WriteTable(w io.Writer) error {
+	list := s.List()
+	sort.SliceStable(list, func(i, j int) bool {
+		return list[i].Time > list[j].Time
+	})
+
+	// REJECTS is the last column, so the reject reason rows
+	// don't break the tabwriter column alignment.
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "RULE\tTIME\tCANDIDATES\tMATCHES\tREPORTS\tREJECTS")
+	for _, st := range list {
+		rejects := 0
+		reasons := make([]string, 0, len(st.Rejects))
+		for reason, n := range st.Rejects {
+			rejects += n
+			reasons = append(reasons, reason)
+		}
+		sort.Slice(reasons, func(i, j int) bool {
+			x := st.Rejects[reasons[i]]
+			y := st.Rejects[reasons[j]]
+			if x != y {
+				return x > y
+			}
+			return reasons[i] < reasons[j]
+		})
+
+		fmt.Fprintf(tw, "%s (%s:%d)\t%s\t%d\t%d\t%d\t%d\n",
+			st.Group.Name, filepath.Base(st.Group.Filename), st.Line,
+			st.Time.Round(time.Microsecond), st.Candidates, st.Matches, st.Reports, rejects)
+		for _, reason := range reasons {
+			// Filters can span several lines in the rules file.
+			reasonText := strings.Join(strings.Fields(reason), " ")
+			fmt.Fprintf(tw, "  %s\t\t\t\t\t%d\n", reasonText, st.Rejects[reason])
+		}
+	}
+	return tw.Flush()
+}
+
+// Merge adds the stats collected by other to s.
+func (s *RuleStats) Merge(other *RuleStats) {
+	local := make(map[ruleStatsKey]*RuleStat)
+	for _, st := range other.List() {
+		st := st
+		local[ruleStatsKey{group: st.Group, line: st.Line}] = &st
+	}
+	s.merge(local)
+}
+
+func (s *RuleStats) merge(local map[ruleStatsKey]*RuleStat) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for key, st := range local {
+		dst := s.rules[key]
+		if dst == nil {
+			dst = &RuleStat{Group: st.Group, Line: st.Line, Rejects: make(map[string]int)}
+			s.rules[key] = dst
+		}
+		dst.Candidates += st.Candidates
+		dst.Matches += st.Matches
+		dst.Reports += st.Reports
+		dst.Time += st.Time
+		for reason, n := range st.Rejects {
+			dst.Rejects[reason] += n
+		}
+	}
+}
+
+type ruleStatsKey struct {
+	group *GoRuleGroup
+	line  int
+}
+
+// ruleStat returns the current file stats for the specified rule.
+// Should only be called when rr.stats is not nil.
+func (rr *rulesRunner) ruleStat(group *GoRuleGroup, line int) *RuleStat {
+	key := ruleStatsKey{group: group, line: line}
+	st := rr.stats[key]
+	if st == nil {
+		st = &RuleStat{Group: group, Line: line}
+		rr.stats[key] = st
+	}
+	return st
+}
+
+func (rr *rulesRunner) recordReject(group *GoRuleGroup, line int, reason string) {
+	if rr.stats == nil {
+		return
+	}
+	st := rr.ruleStat(group, line)
+	if st.Rejects == nil {
+		st.Rejects = make(map[string]int)
+	}
+	st.Rejects[reason]++
+}
+
+func (rr *rulesRunner) recordReport(group *GoRuleGroup, line int) {
+	if rr.stats == nil {
+		return
+	}
+	rr.ruleStat(group, line).Reports++
+}
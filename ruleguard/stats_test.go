@@ -0,0 +1,173 @@
+package ruleguard
+
+import (
+	"go/ast"
+	"go/importer"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestRuleStats(t *testing.T) {
+	rules := `
+	package gorules
+
+	import "github.com/quasilyte/go-ruleguard/dsl"
+
+	func constPrefix(m dsl.Matcher) {
+		m.Match("strings.HasPrefix($s, $prefix)").
+			Where(m["prefix"].Const).
+			Report("const prefix")
+	}
+
+	func todoComment(m dsl.Matcher) {
+		m.MatchComment("// TODO").Report("todo comment")
+	}
+	`
+	src := `package example
+
+import "strings"
+
+// TODO: remove.
+func f(s string) bool {
+	return strings.HasPrefix(s, "http://") ||
+		strings.HasPrefix("http://", s) ||
+		len(s) == 0
+}
+`
+
+	e := NewEngine()
+	if err := e.Load(&LoadContext{Fset: token.NewFileSet()}, "rules.go", strings.NewReader(rules)); err != nil {
+		t.Fatalf("load rules: %v", err)
+	}
+
+	fset := token.NewFileSet()
+	f, err := parser.ParseFile(fset, "example.go", src, parser.ParseComments)
+	if err != nil {
+		t.Fatalf("parse: %v", err)
+	}
+	typesInfo := &types.Info{
+		Types: map[ast.Expr]types.TypeAndValue{},
+		Uses:  map[*ast.Ident]types.Object{},
+		Defs:  map[*ast.Ident]types.Object{},
+	}
+	typechecker := types.Config{Importer: importer.ForCompiler(fset, "source", nil)}
+	pkg, err := typechecker.Check("example", fset, []*ast.File{f}, typesInfo)
+	if err != nil {
+		t.Fatalf("typecheck: %v", err)
+	}
+
+	stats := NewRuleStats()
+	ctx := &RunContext{
+		Pkg:    pkg,
+		Types:  typesInfo,
+		Fset:   fset,
+		Stats:  stats,
+		Report: func(*ReportData) {},
+	}
+	// Run twice to check that the stats are accumulated.
+	for i := 0; i < 2; i++ {
+		if err := e.Run(ctx, f); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	type ruleStat struct {
+		Group      string
+		Line       int
+		Candidates int
+		Matches    int
+		Rejects    map[string]int
+		Reports    int
+	}
+	var have []ruleStat
+	for _, st := range stats.List() {
+		if st.Time <= 0 && st.Candidates != 0 {
+			t.Errorf("%s:%d: time is not recorded", st.Group.Name, st.Line)
+		}
+		have = append(have, ruleStat{
+			Group:      st.Group.Name,
+			Line:       st.Line,
+			Candidates: st.Candidates,
+			Matches:    st.Matches,
+			Rejects:    st.Rejects,
+			Reports:    st.Reports,
+		})
+	}
+	want := []ruleStat{
+		{
+			Group:      "constPrefix",
+			Line:       7,
+			Candidates: 6,
+			Matches:    4,
+			Rejects:    map[string]int{`m["prefix"].Const`: 2},
+			Reports:    2,
+		},
+		{
+			Group:      "todoComment",
+			Line:       13,
+			Candidates: 2,
+			Matches:    2,
+			Rejects:    map[string]int{},
+			Reports:    2,
+		},
+	}
+	if diff := cmp.Diff(want, have); diff != "" {
+		t.Errorf("stats mismatch (-want +have):\n%s", diff)
+	}
+}
+
+func TestRuleStatsWriteTable(t *testing.T) {
+	fast := &GoRuleGroup{Name: "fast", Filename: "/home/user/rules.go"}
+	slow := &GoRuleGroup{Name: "slow", Filename: "/home/user/rules.go"}
+	local := map[ruleStatsKey]*RuleStat{
+		{group: fast, line: 10}: {
+			Group:      fast,
+			Line:       10,
+			Candidates: 100,
+			Matches:    1,
+			Reports:    1,
+			Time:       1500 * time.Microsecond,
+		},
+		{group: slow, line: 20}: {
+			Group:      slow,
+			Line:       20,
+			Candidates: 2500,
+			Matches:    20,
+			Rejects: map[string]int{
+				`m["x"].Pure`: 1,
+				"m[\"x\"].Type.Is(\"int\") &&\n\t\tm[\"y\"].Const": 17,
+			},
+			Reports: 2,
+			Time:    time.Millisecond,
+		},
+	}
+	other := NewRuleStats()
+	other.merge(local)
+	slowOnly := NewRuleStats()
+	slowOnly.merge(map[ruleStatsKey]*RuleStat{{group: slow, line: 20}: local[ruleStatsKey{group: slow, line: 20}]})
+
+	// The slow rule stats are summed up by Merge.
+	stats := NewRuleStats()
+	stats.Merge(other)
+	stats.Merge(slowOnly)
+
+	var buf strings.Builder
+	if err := stats.WriteTable(&buf); err != nil {
+		t.Fatal(err)
+	}
+	want := `RULE                                     TIME   CANDIDATES  MATCHES  REPORTS  REJECTS
+slow (rules.go:20)                       2ms    5000        40       4        36
+  m["x"].Type.Is("int") && m["y"].Const                                       34
+  m["x"].Pure                                                                 2
+fast (rules.go:10)                       1.5ms  100         1        1        0
+`
+	if diff := cmp.Diff(want, buf.String()); diff != "" {
+		t.Errorf("stats output mismatch (-want +have):\n%s", diff)
+	}
+}
@@ -0,0 +1,163 @@
+package ruleguard
+
+import (
+	"go/ast"
+	"go/token"
+	"strings"
+)
+
+const ignoreDirective = "//ruleguard:ignore"
+
+// suppression is a parsed //ruleguard:ignore directive.
+//
+// A directive suppresses the matching reports that start on the same line.
+// If it's a part of a declaration doc comment, it suppresses
+// the matching reports inside that declaration as well.
+type suppression struct {
+	comment *ast.Comment
+	line    int
+
+	// from and to describe the annotated declaration range.
+	// They're token.NoPos if there is no such declaration.
+	from token.Pos
+	to   token.Pos
+
+	// groups is a list of the suppressed rule group names.
+	// An empty list means "all groups".
+	groups []string
+
+	used bool
+}
+
+func (s *suppression) matches(groupName string) bool {
+	if len(s.groups) == 0 {
+		return true
+	}
+	for _, g := range s.groups {
+		if g == groupName {
+			return true
+		}
+	}
+	return false
+}
+
+// parseIgnoreDirective returns a list of group names from the directive comment.
+// The second result is false if comment is not a ruleguard:ignore directive.
+//
+// Everything after the nested "//" is treated as a free-form explanation:
+//
+//	//ruleguard:ignore foo,bar // false positive, see #123
+func parseIgnoreDirective(text string) ([]string, bool) {
+	if !strings.HasPrefix(text, ignoreDirective) {
+		return nil, false
+	}
+	rest := text[len(ignoreDirective):]
+	if rest != "" && rest[0] != ' ' && rest[0] != '\t' {
+		return nil, false // Some other directive, like //ruleguard:ignorefoo
+	}
+	if i := strings.Index(rest, "//"); i != -1 {
+		rest = rest[:i]
+	}
+	groups := strings.FieldsFunc(rest, func(ch rune) bool {
+		return ch == ',' || ch == ' ' || ch == '\t'
+	})
+	return groups, true
+}
+
+func (rr *rulesRunner) collectSuppressions(f *ast.File) {
+	rr.suppressions = rr.suppressions[:0]
+
+	for _, commentGroup := range f.Comments {
+		for _, comment := range commentGroup.List {
+			groups, ok := parseIgnoreDirective(comment.Text)
+			if !ok {
+				continue
+			}
+			rr.suppressions = append(rr.suppressions, suppression{
+				comment: comment,
+				line:    rr.ctx.Fset.Position(comment.Pos()).Line,
+				groups:  groups,
+			})
+		}
+	}
+	if len(rr.suppressions) == 0 {
+		return
+	}
+
+	bindDoc := func(doc *ast.CommentGroup, decl ast.Node) {
+		if doc == nil {
+			return
+		}
+		for i := range rr.suppressions {
+			s := &rr.suppressions[i]
+			if s.comment.Pos() >= doc.Pos() && s.comment.End() <= doc.End() {
+				s.from = decl.Pos()
+				s.to = decl.End()
+			}
+		}
+	}
+	for _, decl := range f.Decls {
+		switch decl := decl.(type) {
+		case *ast.FuncDecl:
+			bindDoc(decl.Doc, decl)
+		case *ast.GenDecl:
+			bindDoc(decl.Doc, decl)
+			for _, spec := range decl.Specs {
+				switch spec := spec.(type) {
+				case *ast.ValueSpec:
+					bindDoc(spec.Doc, spec)
+				case *ast.TypeSpec:
+					bindDoc(spec.Doc, spec)
+				}
+			}
+		}
+	}
+}
+
+// isSuppressed reports whether a node report from the specified group
+// should be suppressed by one of the ruleguard:ignore directives.
+func (rr *rulesRunner) isSuppressed(group *GoRuleGroup, node ast.Node) bool {
+	return isSuppressed(rr.ctx.Fset, rr.suppressions, group, node)
+}
+
+func isSuppressed(fset *token.FileSet, suppressions []suppression, group *GoRuleGroup, node ast.Node) bool {
+	if len(suppressions) == 0 {
+		return false
+	}
+
+	pos := node.Pos()
+	line := fset.Position(pos).Line
+	suppressed := false
+	for i := range suppressions {
+		s := &suppressions[i]
+		inDecl := s.from.IsValid() && pos >= s.from && pos < s.to
+		if (s.line != line && !inDecl) || !s.matches(group.Name) {
+			continue
+		}
+		// Don't stop at the first match: all directives that
+		// suppress this report are considered to be used.
+		s.used = true
+		suppressed = true
+	}
+	return suppressed
+}
+
+func reportUnusedSuppressions(ctx *RunContext, rules *goRuleSet, suppressions []suppression) {
+	for _, s := range suppressions {
+		if s.used {
+			continue
+		}
+		// If some of the groups are not loaded (e.g. they're disabled),
+		// we can't tell whether this directive is unused.
+		allLoaded := true
+		for _, g := range s.groups {
+			if _, ok := rules.groups[g]; !ok {
+				allLoaded = false
+				break
+			}
+		}
+		if allLoaded {
+			ctx.ReportUnusedSuppression(s.comment)
+		}
+	}
+}
@@ -0,0 +1,8 @@
+package runpackages
+
+func a(x int) {
+	if x == x {
+		println(x)
+	}
+	_ = len("a") >= 0
+}
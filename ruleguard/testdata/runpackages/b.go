@@ -0,0 +1,5 @@
+package runpackages
+
+func b(s []int) bool {
+	return len(s) >= 0 || len(s) >= 0
+}
@@ -22,11 +22,14 @@ func _() {
 	_ = x[opStruct-11]
 	_ = x[opAnyInterface-12]
 	_ = x[opNamed-13]
+	_ = x[opGenericNamed-14]
+	_ = x[opTypeParam-15]
+	_ = x[opInterface-16]
 }
 
-const _patternOp_name = "opBuiltinTypeopPointeropVaropVarSeqopSliceopArrayopMapopChanopFuncNoSeqopFuncopStructNoSeqopStructopAnyInterfaceopNamed"
+const _patternOp_name = "opBuiltinTypeopPointeropVaropVarSeqopSliceopArrayopMapopChanopFuncNoSeqopFuncopStructNoSeqopStructopAnyInterfaceopNamedopGenericNamedopTypeParamopInterface"
 
-var _patternOp_index = [...]uint8{0, 13, 22, 27, 35, 42, 49, 54, 60, 71, 77, 90, 98, 112, 119}
+var _patternOp_index = [...]uint8{0, 13, 22, 27, 35, 42, 49, 54, 60, 71, 77, 90, 98, 112, 119, 133, 144, 155}
 
 func (i patternOp) String() string {
 	if i < 0 || i >= patternOp(len(_patternOp_index)-1) {
@@ -30,6 +30,9 @@ const (
 	opStruct
 	opAnyInterface
 	opNamed
+	opGenericNamed
+	opTypeParam
+	opInterface
 )
 
 type MatcherState struct {
@@ -115,6 +118,9 @@ type Context struct {
 const (
 	varPrefix    = `ᐸvarᐳ`
 	varSeqPrefix = `ᐸvar_seqᐳ`
+
+	// typeParamName is a pseudo type name that matches type parameters.
+	typeParamName = `typeparam`
 )
 
 func Parse(ctx *Context, s string) (*Pattern, error) {
@@ -154,7 +160,10 @@ var (
 		"complex128": types.Typ[types.Complex128],
 		"string":     types.Typ[types.String],
 
-		"error": types.Universe.Lookup("error").Type(),
+		"error":      types.Universe.Lookup("error").Type(),
+		"comparable": types.Universe.Lookup("comparable").Type(),
+
+		"any": efaceType,
 
 		// Aliases.
 		"byte": types.Typ[types.Uint8],
@@ -182,6 +191,10 @@ func parseExpr(ctx *Context, e ast.Expr) *pattern {
 				return &pattern{op: opVarSeq, value: name}
 			}
 		}
+		if e.Name == typeParamName {
+			// typeparam is a shorthand for typeparam[$_].
+			return &pattern{op: opTypeParam, subs: []*pattern{{op: opVar, value: "_"}}}
+		}
 
 	case *ast.SelectorExpr:
 		pkg, ok := e.X.(*ast.Ident)
@@ -197,6 +210,12 @@ func parseExpr(ctx *Context, e ast.Expr) *pattern {
 		}
 		return &pattern{op: opNamed, value: [2]string{pkgPath, e.Sel.Name}}
 
+	case *ast.IndexExpr:
+		return parseGenericNamed(ctx, e.X, []ast.Expr{e.Index})
+
+	case *ast.IndexListExpr:
+		return parseGenericNamed(ctx, e.X, e.Indices)
+
 	case *ast.StarExpr:
 		elem := parseExpr(ctx, e.X)
 		if elem == nil {
@@ -349,21 +368,79 @@ func parseExpr(ctx *Context, e ast.Expr) *pattern {
 		if len(e.Methods.List) == 0 {
 			return &pattern{op: opBuiltinType, value: efaceType}
 		}
-		if len(e.Methods.List) == 1 {
-			p := parseExpr(ctx, e.Methods.List[0].Type)
-			if p == nil {
-				return nil
-			}
-			if p.op != opVarSeq {
-				return nil
-			}
-			return &pattern{op: opAnyInterface}
-		}
+		return parseInterface(ctx, e)
 	}
 
 	return nil
 }
 
+// interfacePattern describes the opInterface pattern.
+// Its subs are method signatures followed by the embedded types.
+type interfacePattern struct {
+	methods []string
+
+	// hasSeq is true for the `interface{ ...; $*_ }` patterns.
+	// Such interfaces can have any extra methods.
+	hasSeq bool
+}
+
+func parseInterface(ctx *Context, e *ast.InterfaceType) *pattern {
+	info := &interfacePattern{}
+	var methods []*pattern
+	var embedded []*pattern
+	for _, field := range e.Methods.List {
+		p := parseExpr(ctx, field.Type)
+		if p == nil {
+			return nil
+		}
+		switch {
+		case len(field.Names) == 1:
+			info.methods = append(info.methods, field.Names[0].Name)
+			methods = append(methods, p)
+		case p.op == opVarSeq:
+			info.hasSeq = true
+		default:
+			embedded = append(embedded, p)
+		}
+	}
+	if info.hasSeq && len(methods) == 0 && len(embedded) == 0 {
+		return &pattern{op: opAnyInterface}
+	}
+	return &pattern{
+		op:    opInterface,
+		value: info,
+		subs:  append(methods, embedded...),
+	}
+}
+
+func parseGenericNamed(ctx *Context, x ast.Expr, args []ast.Expr) *pattern {
+	// typeparam[C] matches a type parameter with a constraint that matches C.
+	if id, ok := x.(*ast.Ident); ok && id.Name == typeParamName {
+		if len(args) != 1 {
+			return nil
+		}
+		constraint := parseExpr(ctx, args[0])
+		if constraint == nil {
+			return nil
+		}
+		return &pattern{op: opTypeParam, subs: []*pattern{constraint}}
+	}
+
+	base := parseExpr(ctx, x)
+	if base == nil || base.op != opNamed {
+		return nil
+	}
+	subs := make([]*pattern, len(args))
+	for i, arg := range args {
+		p := parseExpr(ctx, arg)
+		if p == nil {
+			return nil
+		}
+		subs[i] = p
+	}
+	return &pattern{op: opGenericNamed, value: base.value, subs: subs}
+}
+
 // MatchIdentical returns true if the go typ matches pattern p.
 func (p *Pattern) MatchIdentical(state *MatcherState, typ types.Type) bool {
 	state.reset()
@@ -497,24 +574,32 @@ func (p *Pattern) matchIdentical(state *MatcherState, sub *pattern, typ types.Ty
 		if !ok {
 			return false
 		}
-		obj := typ.Obj()
-		pkg := obj.Pkg()
-		// pkg can be nil for builtin named types.
-		// There is no point in checking anything else as we never
-		// generate the opNamed for such types.
-		if pkg == nil {
+		return matchNamed(sub.value.([2]string), typ)
+
+	case opGenericNamed:
+		typ, ok := typ.(*types.Named)
+		if !ok || typ.TypeArgs().Len() == 0 {
 			return false
 		}
-		pkgPath := sub.value.([2]string)[0]
-		typeName := sub.value.([2]string)[1]
-		if typeName != obj.Name() {
+		if !matchNamed(sub.value.([2]string), typ) {
 			return false
 		}
-		objPath := obj.Pkg().Path()
-		if vendorPos := strings.Index(objPath, "/vendor/"); vendorPos != -1 {
-			objPath = objPath[vendorPos+len("/vendor/"):]
+		adapter := typeListFielder{x: typ.TypeArgs()}
+		return p.matchIdenticalFielder(state, sub.subs, &adapter)
+
+	case opTypeParam:
+		typ, ok := typ.(*types.TypeParam)
+		if !ok {
+			return false
 		}
-		return objPath == pkgPath
+		constraint := typ.Constraint()
+		if sub.subs[0].op == opBuiltinType && sub.subs[0].value == efaceType {
+			// Type set constraints like `~int | ~string` have no methods,
+			// but they're not identical to any.
+			iface, ok := constraint.Underlying().(*types.Interface)
+			return ok && iface.Empty()
+		}
+		return p.matchIdentical(state, sub.subs[0], constraint)
 
 	case opFuncNoSeq:
 		typ, ok := typ.(*types.Signature)
@@ -586,14 +671,139 @@ func (p *Pattern) matchIdentical(state *MatcherState, sub *pattern, typ types.Ty
 		return true
 
 	case opAnyInterface:
-		_, ok := typ.(*types.Interface)
+		_, ok := interfaceOf(typ)
 		return ok
 
+	case opInterface:
+		iface, ok := interfaceOf(typ)
+		if !ok {
+			return false
+		}
+		return p.matchInterface(state, sub, iface)
+
 	default:
 		return false
 	}
 }
 
+// interfaceOf returns the interface type of typ.
+// Named interfaces like io.ReadCloser are resolved to their underlying types.
+// Type parameters are not interfaces, even though their
+// underlying type is the constraint interface.
+func interfaceOf(typ types.Type) (*types.Interface, bool) {
+	if _, ok := typ.(*types.TypeParam); ok {
+		return nil, false
+	}
+	iface, ok := typ.Underlying().(*types.Interface)
+	return iface, ok
+}
+
+func (p *Pattern) matchInterface(state *MatcherState, sub *pattern, typ *types.Interface) bool {
+	info := sub.value.(*interfacePattern)
+	methods := sub.subs[:len(info.methods)]
+	embedded := sub.subs[len(info.methods):]
+
+	for i, name := range info.methods {
+		m := findInterfaceMethod(typ, name)
+		if m == nil || !p.matchIdentical(state, methods[i], m.Type()) {
+			return false
+		}
+	}
+
+	var embeddedTypes []*types.Interface
+	for _, pat := range embedded {
+		embeddedType := p.findEmbedded(state, pat, typ)
+		if embeddedType == nil {
+			return false
+		}
+		if !info.hasSeq {
+			iface, _ := embeddedType.Underlying().(*types.Interface)
+			embeddedTypes = append(embeddedTypes, iface)
+		}
+	}
+
+	if info.hasSeq {
+		return true
+	}
+
+	// Without $*_, every interface method should be
+	// either listed explicitly or come from the embedded types.
+	for i := 0; i < typ.NumMethods(); i++ {
+		name := typ.Method(i).Name()
+		if containsString(info.methods, name) {
+			continue
+		}
+		covered := false
+		for _, iface := range embeddedTypes {
+			if iface != nil && findInterfaceMethod(iface, name) != nil {
+				covered = true
+				break
+			}
+		}
+		if !covered {
+			return false
+		}
+	}
+	return true
+}
+
+// findEmbedded returns an embedded type that matches the pattern.
+// Embedded interfaces are inspected recursively, so io.Reader
+// is found inside the interfaces that embed io.ReadCloser.
+func (p *Pattern) findEmbedded(state *MatcherState, pat *pattern, typ *types.Interface) types.Type {
+	for i := 0; i < typ.NumEmbeddeds(); i++ {
+		embedded := typ.EmbeddedType(i)
+		if p.matchIdentical(state, pat, embedded) {
+			return embedded
+		}
+		if iface, ok := embedded.Underlying().(*types.Interface); ok {
+			if found := p.findEmbedded(state, pat, iface); found != nil {
+				return found
+			}
+		}
+	}
+	return nil
+}
+
+func findInterfaceMethod(typ *types.Interface, name string) *types.Func {
+	for i := 0; i < typ.NumMethods(); i++ {
+		if m := typ.Method(i); m.Name() == name {
+			return m
+		}
+	}
+	return nil
+}
+
+func containsString(list []string, s string) bool {
+	for _, x := range list {
+		if x == s {
+			return true
+		}
+	}
+	return false
+}
+
+func matchNamed(value [2]string, typ *types.Named) bool {
+	obj := typ.Obj()
+	pkg := obj.Pkg()
+	// pkg can be nil for builtin named types.
+	// There is no point in checking anything else as we never
+	// generate the opNamed for such types.
+	if pkg == nil {
+		return false
+	}
+	pkgPath := value[0]
+	typeName := value[1]
+	if typeName != obj.Name() {
+		return false
+	}
+	objPath := pkg.Path()
+	if vendorPos := strings.Index(objPath, "/vendor/"); vendorPos != -1 {
+		objPath = objPath[vendorPos+len("/vendor/"):]
+	}
+	return objPath == pkgPath
+}
+
 type fielder interface {
 	Field(i int) *types.Var
 	NumFields() int
@@ -605,3 +815,14 @@ type tupleFielder struct {
 
 func (tup *tupleFielder) Field(i int) *types.Var { return tup.x.At(i) }
 func (tup *tupleFielder) NumFields() int         { return tup.x.Len() }
+
+// typeListFielder adapts the generic type arguments list.
+type typeListFielder struct {
+	x *types.TypeList
+}
+
+func (list *typeListFielder) Field(i int) *types.Var {
+	return types.NewVar(token.NoPos, nil, "", list.x.At(i))
+}
+
+func (list *typeListFielder) NumFields() int { return list.x.Len() }
@@ -20,6 +20,24 @@ var (
 			types.NewSignatureType(nil, nil, nil, types.NewTuple(), types.NewTuple(types.NewVar(token.NoPos, nil, "result", typeString)), false)),
 	}, nil)
 
+	typeComparable = types.Universe.Lookup("comparable").Type()
+	typeIntSet     = types.NewInterfaceType(nil, []types.Type{
+		types.NewUnion([]*types.Term{types.NewTerm(true, typeInt), types.NewTerm(true, typeString)}),
+	}).Complete()
+
+	atomicPointer = genericType("sync/atomic", "Pointer", 1)
+	mypkgSet      = genericType("example.com/mypkg", "Set", 2)
+
+	typeError  = types.Universe.Lookup("error").Type()
+	closeFunc  = methodFunc("Close", nil, types.NewTuple(types.NewVar(token.NoPos, nil, "", typeError)))
+	stringFunc = methodFunc("String", nil, types.NewTuple(types.NewVar(token.NoPos, nil, "", typeString)))
+	readFunc   = methodFunc("Read", types.NewTuple(types.NewVar(token.NoPos, nil, "", types.NewSlice(typeUint8))),
+		types.NewTuple(types.NewVar(token.NoPos, nil, "", typeInt), types.NewVar(token.NoPos, nil, "", typeError)))
+
+	ioReader     = namedInterface("io", "Reader", []*types.Func{readFunc}, nil)
+	ioCloser     = namedInterface("io", "Closer", []*types.Func{closeFunc}, nil)
+	ioReadCloser = namedInterface("io", "ReadCloser", nil, []types.Type{ioReader, ioCloser})
+
 	intVar     = types.NewVar(token.NoPos, nil, "_", typeInt)
 	int32Var   = types.NewVar(token.NoPos, nil, "_", typeInt32)
 	estructVar = types.NewVar(token.NoPos, nil, "_", typeEstruct)
@@ -29,6 +47,8 @@ var (
 		Itab: NewImportsTab(map[string]string{
 			"io":     "io",
 			"syntax": "regexp/syntax",
+			"atomic": "sync/atomic",
+			"mypkg":  "example.com/mypkg",
 		}),
 	}
 )
@@ -48,6 +68,50 @@ func namedType(pkgPath, pkgName, typeName string) *types.Named {
 	return types.NewNamed(typename, dummy, nil)
 }
 
+func namedIface(pkgPath, typeName string) *types.Named {
+	pkg := types.NewPackage(pkgPath, path.Base(pkgPath))
+	typename := types.NewTypeName(0, pkg, typeName, nil)
+	return types.NewNamed(typename, types.NewInterfaceType(nil, nil), nil)
+}
+
+func methodFunc(name string, params, results *types.Tuple) *types.Func {
+	return types.NewFunc(token.NoPos, nil, name, types.NewSignatureType(nil, nil, nil, params, results, false))
+}
+
+func interfaceType(methods []*types.Func, embeddeds []types.Type) *types.Interface {
+	return types.NewInterfaceType(methods, embeddeds).Complete()
+}
+
+func namedInterface(pkgPath, typeName string, methods []*types.Func, embeddeds []types.Type) *types.Named {
+	pkg := types.NewPackage(pkgPath, path.Base(pkgPath))
+	typename := types.NewTypeName(0, pkg, typeName, nil)
+	return types.NewNamed(typename, interfaceType(methods, embeddeds), nil)
+}
+
+func genericType(pkgPath, typeName string, numParams int) *types.Named {
+	pkg := types.NewPackage(pkgPath, path.Base(pkgPath))
+	typename := types.NewTypeName(0, pkg, typeName, nil)
+	named := types.NewNamed(typename, typeEstruct, nil)
+	tparams := make([]*types.TypeParam, numParams)
+	for i := range tparams {
+		tparams[i] = typeParam(types.NewInterfaceType(nil, nil))
+	}
+	named.SetTypeParams(tparams)
+	return named
+}
+
+func instantiate(generic *types.Named, args ...types.Type) types.Type {
+	typ, err := types.Instantiate(nil, generic, args, false)
+	if err != nil {
+		panic(err)
+	}
+	return typ
+}
+
+func typeParam(constraint types.Type) *types.TypeParam {
+	return types.NewTypeParam(types.NewTypeName(0, nil, "T", nil), constraint)
+}
+
 func TestIdentical(t *testing.T) {
 	tests := []struct {
 		expr string
@@ -64,6 +128,25 @@ func TestIdentical(t *testing.T) {
 
 		{`interface{}`, types.NewInterfaceType(nil, nil)},
 		{`interface{ $*_ }`, stringerIface},
+		{`interface{ $*_ }`, ioCloser},
+		{`interface{ $*_ }`, ioReadCloser},
+
+		{`interface{ Close() error }`, interfaceType([]*types.Func{closeFunc}, nil)},
+		{`interface{ Close() $_ }`, interfaceType([]*types.Func{closeFunc}, nil)},
+		{`interface{ Close() error; String() string }`, interfaceType([]*types.Func{stringFunc, closeFunc}, nil)},
+		{`interface{ Close() error; $*_ }`, interfaceType([]*types.Func{closeFunc}, nil)},
+		{`interface{ Close() error; $*_ }`, interfaceType([]*types.Func{closeFunc, stringFunc}, nil)},
+		{`interface{ $*_; Close() error }`, interfaceType([]*types.Func{closeFunc, stringFunc}, nil)},
+		{`interface{ Close() error; $*_ }`, interfaceType(nil, []types.Type{ioReadCloser})},
+		{`interface{ io.ReadCloser }`, interfaceType(nil, []types.Type{ioReadCloser})},
+		{`interface{ io.Reader; $*_ }`, interfaceType([]*types.Func{stringFunc}, []types.Type{ioReadCloser})},
+		{`interface{ io.Reader; Close() error }`, interfaceType(nil, []types.Type{ioReadCloser})},
+		{`interface{ io.Reader; io.Closer }`, interfaceType(nil, []types.Type{ioReadCloser})},
+		{`interface{ Close() error; $*_ }`, ioCloser},
+		{`interface{ Close() error; $*_ }`, ioReadCloser},
+		{`interface{ io.Reader; io.Closer }`, ioReadCloser},
+		{`interface{ Close() error; $*_ }`, namedInterface("example.com/mypkg", "RC", []*types.Func{stringFunc}, []types.Type{ioReadCloser})},
+		{`[]interface{ io.Reader; $*_ }`, types.NewSlice(namedInterface("example.com/mypkg", "RC", []*types.Func{stringFunc}, []types.Type{ioReadCloser}))},
 
 		{`$t`, typeInt},
 		{`*$t`, types.NewPointer(typeInt)},
@@ -95,6 +178,29 @@ func TestIdentical(t *testing.T) {
 		{`unsafe.Pointer`, typeUnsafePtr},
 		{`[]unsafe.Pointer`, types.NewSlice(typeUnsafePtr)},
 
+		{`any`, types.NewInterfaceType(nil, nil)},
+		{`[]any`, types.NewSlice(types.NewInterfaceType(nil, nil))},
+
+		{`atomic.Pointer`, instantiate(atomicPointer, typeInt)},
+		{`atomic.Pointer[int]`, instantiate(atomicPointer, typeInt)},
+		{`atomic.Pointer[$t]`, instantiate(atomicPointer, typeString)},
+		{`*atomic.Pointer[$_]`, types.NewPointer(instantiate(atomicPointer, typeInt))},
+		{`mypkg.Set[$k, $v]`, instantiate(mypkgSet, typeInt, typeString)},
+		{`mypkg.Set[$t, $t]`, instantiate(mypkgSet, typeInt, typeInt)},
+		{`mypkg.Set[string, []$_]`, instantiate(mypkgSet, typeString, types.NewSlice(typeInt))},
+		{`mypkg.Set[$*_]`, instantiate(mypkgSet, typeInt, typeString)},
+		{`mypkg.Set[int, $*_]`, instantiate(mypkgSet, typeInt, typeString)},
+
+		{`typeparam`, typeParam(typeComparable)},
+		{`typeparam[$_]`, typeParam(typeComparable)},
+		{`typeparam[any]`, typeParam(types.NewInterfaceType(nil, nil))},
+		{`typeparam[comparable]`, typeParam(typeComparable)},
+		{`typeparam[interface{ $*_ }]`, typeParam(typeIntSet)},
+		{`typeparam[io.Reader]`, typeParam(namedIface("io", "Reader"))},
+		{`*typeparam`, types.NewPointer(typeParam(typeIntSet))},
+		{`[]typeparam[$_]`, types.NewSlice(typeParam(typeIntSet))},
+		{`map[typeparam[comparable]]int`, types.NewMap(typeParam(typeComparable), typeInt)},
+
 		{`func()`, types.NewSignatureType(nil, nil, nil, nil, nil, false)},
 		{`func(int)`, types.NewSignatureType(nil, nil, nil, types.NewTuple(intVar), nil, false)},
 		{`func(int, string)`, types.NewSignatureType(nil, nil, nil, types.NewTuple(intVar, stringVar), nil, false)},
@@ -185,9 +291,39 @@ func TestIdenticalNegative(t *testing.T) {
 		{`unsafe.Pointer`, types.NewPointer(typeInt)},
 		{`[]unsafe.Pointer`, types.NewSlice(typeInt)},
 
+		{`any`, typeInt},
+
+		{`atomic.Pointer[int]`, instantiate(atomicPointer, typeString)},
+		{`atomic.Pointer[$_]`, atomicPointer},
+		{`atomic.Pointer[$_]`, namedType2("sync/atomic", "Pointer")},
+		{`atomic.Pointer[$_]`, instantiate(mypkgSet, typeInt, typeInt)},
+		{`mypkg.Set[$t, $t]`, instantiate(mypkgSet, typeInt, typeString)},
+		{`mypkg.Set[$_]`, instantiate(mypkgSet, typeInt, typeString)},
+		{`mypkg.Set[string, $*_]`, instantiate(mypkgSet, typeInt, typeString)},
+
+		{`typeparam`, typeInt},
+		{`typeparam[$_]`, typeInt},
+		{`typeparam[any]`, typeParam(typeComparable)},
+		{`typeparam[any]`, typeParam(typeIntSet)},
+		{`typeparam[comparable]`, typeParam(types.NewInterfaceType(nil, nil))},
+		{`typeparam[io.Reader]`, typeParam(typeComparable)},
+
 		{`interface{}`, typeInt},
 		{`interface{ $*_ }`, typeString},
 		{`interface{ $*_ }`, types.NewArray(typeString, 10)},
+		{`interface{ $*_ }`, typeParam(ioCloser)},
+
+		{`interface{ Close() error }`, typeInt},
+		{`interface{ Close() error }`, interfaceType([]*types.Func{closeFunc, stringFunc}, nil)},
+		{`interface{ Close() error }`, interfaceType(nil, []types.Type{ioReadCloser})},
+		{`interface{ Close() error; $*_ }`, interfaceType([]*types.Func{stringFunc}, nil)},
+		{`interface{ Close() string; $*_ }`, interfaceType([]*types.Func{closeFunc}, nil)},
+		{`interface{ Close() error }`, ioReadCloser},
+		{`interface{ io.Reader; $*_ }`, ioCloser},
+		{`interface{ Close() error; $*_ }`, typeParam(ioCloser)},
+		{`interface{ io.Reader; $*_ }`, interfaceType([]*types.Func{readFunc}, nil)},
+		{`interface{ io.Reader; $*_ }`, interfaceType(nil, []types.Type{ioCloser})},
+		{`interface{ io.Reader }`, interfaceType(nil, []types.Type{ioReadCloser})},
 
 		{`*$t`, typeInt},
 		{`map[$t]$t`, types.NewMap(typeString, typeInt)},
@@ -4,17 +4,22 @@ import (
 	"go/ast"
 	"go/constant"
 	"go/parser"
+	"go/scanner"
 	"go/token"
 	"go/types"
 	"regexp/syntax"
 	"strconv"
 	"strings"
+	"unicode"
+	"unicode/utf8"
 
 	"golang.org/x/exp/typeparams"
 )
 
 var invalidType = types.Typ[types.Invalid]
 
+var unknownValue = constant.MakeUnknown()
+
 func regexpHasCaptureGroups(pattern string) bool {
 	// regexp.Compile() uses syntax.Perl flags, so
 	// we use the same flags here.
@@ -302,3 +307,97 @@ func isTypeParam(typ types.Type) bool {
 	_, ok := typ.(*typeparams.TypeParam)
 	return ok
 }
+
+// templatePackageRefs returns all package-like qualifiers from the template.
+// For `strings.EqualFold($x, $y)` it returns ["strings"].
+//
+// Selectors that start from a template var (like `$x.Len()`)
+// or are part of a longer selector chain are not included.
+func templatePackageRefs(tmpl string) []string {
+	var refs []string
+
+	var s scanner.Scanner
+	fset := token.NewFileSet()
+	file := fset.AddFile("", -1, len(tmpl))
+	s.Init(file, []byte(tmpl), nil, 0)
+
+	prevTok := token.ILLEGAL
+	prevLit := ""
+	ident := ""
+	for {
+		_, tok, lit := s.Scan()
+		if tok == token.EOF {
+			break
+		}
+		switch {
+		case tok == token.PERIOD && ident != "":
+			refs = append(refs, ident)
+			ident = ""
+		case tok == token.IDENT && prevTok != token.PERIOD && !(prevTok == token.ILLEGAL && prevLit == "$"):
+			ident = lit
+		default:
+			ident = ""
+		}
+		prevTok = tok
+		prevLit = lit
+	}
+
+	return refs
+}
+
+func containsSuggestionImport(list []goRuleSuggestionImport, x goRuleSuggestionImport) bool {
+	for _, y := range list {
+		if x == y {
+			return true
+		}
+	}
+	return false
+}
+
+// isTestFunc reports whether decl is a function that is recognized by the go test.
+func isTestFunc(filename string, decl *ast.FuncDecl) bool {
+	if decl.Recv != nil || !strings.HasSuffix(filename, "_test.go") {
+		return false
+	}
+	name := decl.Name.Name
+	for _, prefix := range []string{"Test", "Benchmark", "Fuzz", "Example"} {
+		if !strings.HasPrefix(name, prefix) {
+			continue
+		}
+		// Like in the go test, TestFoo is a test, but Testfoo is not.
+		suffix := name[len(prefix):]
+		if suffix == "" {
+			return true
+		}
+		ch, _ := utf8.DecodeRuneInString(suffix)
+		return !unicode.IsLower(ch)
+	}
+	return false
+}
+
+// findNodePath returns a list of the n node ancestors inside the root subtree.
+// The first path element is a root itself, the last one is the n node parent.
+// If root is n, an empty path is returned.
+func findNodePath(root, n ast.Node) ([]ast.Node, bool) {
+	var path []ast.Node
+	found := false
+	ast.Inspect(root, func(x ast.Node) bool {
+		if found {
+			return false
+		}
+		if x == nil {
+			path = path[:len(path)-1]
+			return false
+		}
+		if x == n {
+			found = true
+			return false
+		}
+		if x.Pos() > n.Pos() || x.End() < n.End() {
+			return false
+		}
+		path = append(path, x)
+		return true
+	})
+	return path, found
+}